@@ -1,6 +1,7 @@
 package console
 
 import (
+	"fmt"
 	"io"
 	"os"
 	"path/filepath"
@@ -23,8 +24,61 @@ type Application struct {
 	Logo string
 	// Help message for the application.
 	Help string
+	// Prompts for missing required arguments and options when input is interactive.
+	PromptMissing bool
+	// Treats input as interactive, even if it's not a terminal (e.g. to script prompts in tests).
+	ForceInteractive bool
+	// Enables the built-in `-V, --version` global option and `version` command. Both show version
+	// information as text by default. The command has it's own `--output=FORMAT` option (e.g. json,
+	// or yaml), and the global option uses the format given by `-o, --output=FORMAT` if
+	// EnableOutputFormat is set.
+	EnableVersion bool
+	// Enables the built-in `help` command.
+	EnableHelpCommand bool
+	// Enables the built-in `-v, --verbose` (repeatable, e.g. `-vvv`) and `-q, --quiet` global
+	// options, which set the verbosity level of the Output.
+	EnableVerbosity bool
+	// Enables the built-in `--color=WHEN` global option, which controls when colour is used.
+	EnableColorOption bool
+	// Enables the built-in `-o, --output=FORMAT` global option, which sets the format used by
+	// Output.Render (e.g. json, yaml, csv, table, or template=TEMPLATE).
+	EnableOutputFormat bool
+	// Enables the built-in `completion` command, which generates shell completion scripts, and lists
+	// the hidden `__complete` command those scripts use to complete values at runtime in Commands.
+	// The `__complete` command can be run whether or not this is set, so that scripts generated with
+	// GenerateCompletion work.
+	EnableCompletion bool
+	// Enables the hidden `__model` command, which writes a description of the application, and all
+	// of it's commands, arguments, and options as JSON (see DescribeModel).
+	EnableModelCommand bool
+	// Enables external sub-command plugins, executables named `<UsageName>-<command>` found in
+	// PluginDirs or on the PATH.
+	EnablePlugins bool
+	// Directories to search for plugins in, before searching the PATH.
+	PluginDirs []string
+	// The annotations added to option descriptions in help (e.g. `parameters.AnnotateEnvVar |
+	// parameters.AnnotateRequired`). If 0, parameters.DefaultOptionAnnotations are added. Use
+	// parameters.AnnotateNone to add none.
+	OptionAnnotations parameters.OptionAnnotation
+	// The order options are shown in, in help, man pages, and docs. Options are shown in alphabetical
+	// order by default.
+	OptionOrder parameters.OptionOrder
+	// Width to wrap help and output to. If 0, the width is detected using the COLUMNS environment
+	// variable, or the size of the terminal.
+	Width int
+	// Renders help for the application, and it's commands. If nil, a TemplateHelpRenderer with
+	// the default sections is used.
+	HelpRenderer HelpRenderer
+	// Reader to read input from.
+	Reader io.Reader
 	// Writer to write output to.
 	Writer io.Writer
+	// Writer to write errors and diagnostic messages to.
+	ErrWriter io.Writer
+	// Function to run before any command is executed, before any command-level hooks.
+	PreRun PreRunFunc
+	// Function to run after any command is executed, after any command-level hooks.
+	PostRun PostRunFunc
 
 	// The root command, that is run by default, when no other commands are specified.
 	rootCommand *Command
@@ -32,6 +86,12 @@ type Application struct {
 	commands []*Command
 	// Slice of global options.
 	globalOptionDefinitions []OptionDefinition
+	// Slice of free-form help topics, shown by the help command.
+	helpTopics []HelpTopic
+	// Custom styles to register on output, for use as markup tags.
+	styles map[string]Style
+	// Middleware to wrap the execution of every command.
+	middleware []MiddlewareFunc
 	// Application definition
 	definition *Definition
 	// Application input.
@@ -48,7 +108,9 @@ func NewApplication(name string, version string) *Application {
 		Name:       name,
 		UsageName:  filepath.Base(os.Args[0]),
 		Version:    version,
+		Reader:     os.Stdin,
 		Writer:     os.Stdout,
+		ErrWriter:  os.Stderr,
 		definition: NewDefinition(),
 	}
 }
@@ -63,12 +125,23 @@ func (a *Application) Run(argv []string, env []string) int {
 	// up-to-date with what the user has requested their io.Writer to be.
 	a.output = NewOutput(a.Writer)
 
+	if a.ErrWriter != nil {
+		a.output.ErrWriter = a.ErrWriter
+	}
+
+	a.output.env = env
+	a.output.SetWidth(a.Width)
+
+	for name, style := range a.styles {
+		a.output.Formatter().SetStyle(name, style)
+	}
+
 	a.configure(a.definition)
 
 	// TODO: Could we handle global options before we do anything with commands? It wouldn't be too
 	// useful for the `help` argument because we need to know the context (i.e. cmd) we're
 	// running to show the right thing.
-	cmd, path := a.resolveCommand(argv)
+	cmd, path := a.resolveCommand(argv, env)
 	if cmd != nil && cmd.Configure != nil {
 		cmd.Configure(a.definition)
 	}
@@ -76,30 +149,94 @@ func (a *Application) Run(argv []string, env []string) int {
 	// Trim argv so that the command path is not left in and sent to commands.
 	argv = argv[len(path):]
 
-	if a.hasHelpOption(argv) || (cmd == nil || cmd.Execute == nil) {
-		a.showHelp(cmd, path)
+	// Plugins handle all of their own input, so we hand over to them as early as possible.
+	if cmd != nil && cmd.plugin != "" {
+		return a.runPlugin(cmd, argv, env)
+	}
+
+	if cmd != nil && cmd.raw != nil {
+		return cmd.raw(argv)
+	}
+
+	if a.EnableVersion && a.hasVersionOption(argv) {
+		return a.showVersion(argv)
+	}
+
+	if a.hasHelpOption(argv) {
+		a.showHelp(a.output.Writer, cmd, path)
+		return 100
+	}
+
+	// If there's nothing to run, then the application was used incorrectly, so help is shown as an
+	// error instead.
+	if cmd == nil || cmd.Execute == nil {
+		a.showHelp(a.output.ErrWriter, cmd, path)
 		return 100
 	}
 
 	// Assign input to application.
 	a.input = ParseInput(a.definition, argv)
+	a.input.Reader = a.Reader
+
+	if a.EnableVerbosity {
+		a.output.SetVerbosity(a.findVerbosity(a.input))
+	}
+
+	if a.EnableColorOption {
+		var colorMode ColorMode
+
+		// Invalid values are ignored here, they're reported when the input is mapped.
+		if colorMode.Set(a.input.GetOptionValue([]string{"color"})) == nil {
+			a.output.SetColorMode(colorMode)
+		}
+	}
+
+	a.output.Debugf("%s: running '%s'\n", a.Name, strings.Join(append([]string{a.UsageName}, path...), " "))
+
+	if cmd.OutputFormats != nil {
+		a.output.SetFormats(cmd.OutputFormats...)
+	}
+
+	var err error
+
+	if a.PromptMissing || cmd.PromptMissing {
+		prompter := NewPrompter(a.input, a.output)
+		prompter.Interactive = prompter.Interactive || a.ForceInteractive
+
+		err = PromptMissingInput(prompter, a.definition, a.input, env)
+	}
+
+	if err == nil {
+		err = MapInput(a.Name, a.definition, a.input, env)
+	}
+
+	if err == nil && a.EnableOutputFormat && a.input.HasOption([]string{"o", "output"}) {
+		err = a.output.SetFormat(a.input.GetOptionValue([]string{"o", "output"}))
+	}
 
-	err := MapInput(a.Name, a.definition, a.input, env)
 	if err != nil {
-		a.output.Printf("%s: %s\n", a.Name, err.Error())
-		a.output.Printf("Try '%s --help' for more information.\n", a.UsageName)
+		a.showError(err)
+		a.showTryHelp(a.UsageName)
 		return 101
 	}
 
-	err = cmd.Execute(a.input, a.output)
+	execute := a.buildExecuteFunc(cmd, a.findCommandChain(cmd, path))
+
+	err = execute(a.input, a.output)
+
+	// Bars that weren't finished by the command would otherwise be left animating.
+	if progress := a.output.activeProgress(); progress != nil {
+		progress.Stop()
+	}
+
 	if err != nil {
 		helpCommand := a.UsageName
 		if cmd.Name != "" {
 			helpCommand += " " + strings.Join(path, " ")
 		}
 
-		a.output.Printf("%s: %s\n", a.Name, err.Error())
-		a.output.Printf("Try '%s --help' for more information.\n", helpCommand)
+		a.showError(err)
+		a.showTryHelp(helpCommand)
 		return 1
 	}
 
@@ -116,9 +253,17 @@ func (a *Application) AddCommand(command *Command) {
 	a.commands = append(a.commands, command)
 }
 
-// Commands gets the sub-commands on an application.
+// Commands gets the sub-commands on an application, including any enabled built-in commands.
 func (a *Application) Commands() []*Command {
-	return a.commands
+	commands := append([]*Command{}, a.commands...)
+
+	for _, builtin := range a.builtinCommands() {
+		if !a.hasCommand(builtin.Name) {
+			commands = append(commands, builtin)
+		}
+	}
+
+	return commands
 }
 
 // AddGlobalOption adds a global option to the application.
@@ -126,13 +271,124 @@ func (a *Application) AddGlobalOption(definition OptionDefinition) {
 	a.globalOptionDefinitions = append(a.globalOptionDefinitions, definition)
 }
 
+// AddHelpTopic adds a free-form help topic to the application, shown by the help command.
+func (a *Application) AddHelpTopic(topic HelpTopic) {
+	a.helpTopics = append(a.helpTopics, topic)
+}
+
+// HelpTopics gets the help topics on an application.
+func (a *Application) HelpTopics() []HelpTopic {
+	return a.helpTopics
+}
+
+// SetStyle registers a Style with the given name on the application's output, for use as a markup
+// tag (e.g. `<name>text</name>`).
+func (a *Application) SetStyle(name string, style Style) {
+	if a.styles == nil {
+		a.styles = make(map[string]Style)
+	}
+
+	a.styles[name] = style
+}
+
 // SetRootCommand sets the root command for the application
 func (a *Application) SetRootCommand(command *Command) {
 	a.rootCommand = command
 }
 
-// resolveCommand attempts to find the command to run based on the raw input.
-func (a *Application) resolveCommand(args []string) (*Command, []string) {
+// Use adds middleware to the application. Application middleware wraps the execution of every
+// command, and is run in the order it is added, before any command-level middleware.
+func (a *Application) Use(middleware ...MiddlewareFunc) {
+	a.middleware = append(a.middleware, middleware...)
+}
+
+// buildExecuteFunc produces the ExecuteFunc that is actually run for the given command. The chain
+// is the list of commands that were traversed to reach the command, starting with the top-most.
+//
+// Pre-run hooks are run in order from the application down to the command, then the command is
+// executed, then post-run hooks are run in the reverse order. Post-run hooks are always run, even
+// if a pre-run hook or the command itself fails. All of this is wrapped in middleware, with the
+// application's middleware on the outside, followed by each command's in the chain.
+func (a *Application) buildExecuteFunc(cmd *Command, chain []*Command) ExecuteFunc {
+	var preRuns []PreRunFunc
+	var postRuns []PostRunFunc
+	var middleware []MiddlewareFunc
+
+	preRuns = append(preRuns, a.PreRun)
+	middleware = append(middleware, a.middleware...)
+
+	for _, c := range chain {
+		preRuns = append(preRuns, c.PreRun)
+		postRuns = append([]PostRunFunc{c.PostRun}, postRuns...)
+		middleware = append(middleware, c.middleware...)
+	}
+
+	postRuns = append(postRuns, a.PostRun)
+
+	execute := func(input *Input, output *Output) error {
+		var err error
+
+		for _, preRun := range preRuns {
+			if preRun == nil {
+				continue
+			}
+
+			if err = preRun(input, output); err != nil {
+				break
+			}
+		}
+
+		if err == nil {
+			err = cmd.Execute(input, output)
+		}
+
+		for _, postRun := range postRuns {
+			if postRun == nil {
+				continue
+			}
+
+			if postErr := postRun(input, output, err); postErr != nil {
+				err = postErr
+			}
+		}
+
+		return err
+	}
+
+	// Wrap in reverse, so that the first middleware added is the outermost.
+	for i := len(middleware) - 1; i >= 0; i-- {
+		execute = middleware[i](execute)
+	}
+
+	return execute
+}
+
+// findCommandChain finds each command along the given path, ending with the given command. If the
+// path is empty then the command is the only command in the chain (e.g. the root command).
+func (a *Application) findCommandChain(cmd *Command, path []string) []*Command {
+	if len(path) == 0 {
+		return []*Command{cmd}
+	}
+
+	var chain []*Command
+	var container CommandContainer = a
+
+	for _, name := range path {
+		for _, c := range container.Commands() {
+			if c.Name == name {
+				chain = append(chain, c)
+				container = c
+				break
+			}
+		}
+	}
+
+	return chain
+}
+
+// resolveCommand attempts to find the command to run based on the raw input. Plugins are looked for
+// on the PATH in the given environment.
+func (a *Application) resolveCommand(args []string, env []string) (*Command, []string) {
 	var loop func(depth int, container CommandContainer) *Command
 	var path []string
 
@@ -160,6 +416,24 @@ func (a *Application) resolveCommand(args []string) (*Command, []string) {
 			}
 		}
 
+		// Completion scripts can be generated without enabling completion (see GenerateCompletion),
+		// so the command they call back into is always found, even if it isn't in Commands.
+		if depth == 0 && command == nil && args[depth] == completeCommandName {
+			path = append(path, completeCommandName)
+			return newCompleteCommand(a)
+		}
+
+		// Plugins can only be top-level commands, and are only looked for once we know there's no
+		// other command with the same name.
+		if depth == 0 && command == nil && a.EnablePlugins {
+			command = a.findPlugin(args[depth], env)
+
+			if command != nil {
+				path = append(path, command.Name)
+				return command
+			}
+		}
+
 		if command != nil {
 			subCommand := loop(depth+1, command)
 			if subCommand != nil {
@@ -179,6 +453,62 @@ func (a *Application) resolveCommand(args []string) (*Command, []string) {
 	return loop(0, a), path
 }
 
+// builtinCommands returns the built-in commands that have been enabled on the application.
+func (a *Application) builtinCommands() []*Command {
+	var commands []*Command
+
+	if a.EnableHelpCommand {
+		commands = append(commands, newHelpCommand(a))
+	}
+
+	if a.EnableVersion {
+		commands = append(commands, newVersionCommand(a))
+	}
+
+	if a.EnableCompletion {
+		commands = append(commands, newCompletionCommand(a), newCompleteCommand(a))
+	}
+
+	if a.EnableModelCommand {
+		commands = append(commands, newModelCommand(a))
+	}
+
+	return commands
+}
+
+// helpCommands gets the commands listed in the application's help. This includes any plugins, which
+// are otherwise only found when a command can't be (see findPlugin), so they're not included in
+// Commands, or anything generated from it (e.g. docs, or completion scripts).
+func (a *Application) helpCommands() []*Command {
+	commands := a.Commands()
+
+	if a.EnablePlugins {
+		for _, plugin := range a.findPlugins(a.environ()) {
+			if !containsCommand(commands, plugin.Name) {
+				commands = append(commands, plugin)
+			}
+		}
+	}
+
+	return commands
+}
+
+// hasCommand checks to see if a command with the given name has been added to the application.
+func (a *Application) hasCommand(name string) bool {
+	return containsCommand(a.commands, name)
+}
+
+// containsCommand checks to see if a command with the given name is in the given commands.
+func containsCommand(commands []*Command, name string) bool {
+	for _, cmd := range commands {
+		if cmd.Name == name {
+			return true
+		}
+	}
+
+	return false
+}
+
 // hasHelpOption checks to see if a help flag is set, ignoring values. Uses raw args sent to the
 // application.
 func (a *Application) hasHelpOption(args []string) bool {
@@ -191,6 +521,40 @@ func (a *Application) hasHelpOption(args []string) bool {
 	return false
 }
 
+// hasVersionOption checks to see if a version flag is set, ignoring values. Uses raw args sent to
+// the application.
+func (a *Application) hasVersionOption(args []string) bool {
+	for _, arg := range args {
+		if arg == "--version" || arg == "-V" {
+			return true
+		}
+	}
+
+	return false
+}
+
+// showVersion renders version information for the version option, as the version command does. If
+// the output format option is enabled, the format it gives is used.
+func (a *Application) showVersion(argv []string) int {
+	a.output.SetFormats(FormatText, FormatJSON, FormatYAML, FormatTemplate)
+
+	input := ParseInput(a.definition, argv)
+	if a.EnableOutputFormat && input.HasOption([]string{"o", "output"}) {
+		if err := a.output.SetFormat(input.GetOptionValue([]string{"o", "output"})); err != nil {
+			a.showError(err)
+			a.showTryHelp(a.UsageName)
+			return 1
+		}
+	}
+
+	if err := a.output.Render(NewVersionInfo(a)); err != nil {
+		a.showError(err)
+		return 1
+	}
+
+	return 0
+}
+
 // configure configures pre-defined parameters. This is solely defined for help output.
 func (a *Application) configure(definition *Definition) {
 	var help bool
@@ -201,16 +565,134 @@ func (a *Application) configure(definition *Definition) {
 		Desc:  "Display contextual help?",
 	})
 
+	if a.EnableVerbosity {
+		var verbose bool
+		var quiet bool
+
+		definition.AddOption(OptionDefinition{
+			Value: parameters.NewBoolValue(&verbose),
+			Spec:  "-v, --verbose",
+			Desc:  "Increase verbosity of output (repeat for more, e.g. -vvv)?",
+		})
+
+		definition.AddOption(OptionDefinition{
+			Value: parameters.NewBoolValue(&quiet),
+			Spec:  "-q, --quiet",
+			Desc:  "Only output errors and rendered results?",
+		})
+	}
+
+	if a.EnableColorOption {
+		var colorMode ColorMode
+
+		definition.AddOption(OptionDefinition{
+			Value:   &colorMode,
+			Spec:    "--color=WHEN",
+			Desc:    "When to use colour in output.",
+			Choices: []string{"auto", "always", "never"},
+		})
+	}
+
+	if a.EnableOutputFormat {
+		var format string
+
+		definition.AddOption(OptionDefinition{
+			Value: parameters.NewStringValue(&format),
+			Spec:  "-o, --output=FORMAT",
+			Desc:  "Output format, e.g. table, json, yaml, csv, or template=TEMPLATE.",
+		})
+	}
+
+	if a.EnableVersion {
+		var version bool
+
+		definition.AddOption(OptionDefinition{
+			Value: parameters.NewBoolValue(&version),
+			Spec:  "-V, --version",
+			Desc:  "Display version information?",
+		})
+	}
+
 	for _, opt := range a.globalOptionDefinitions {
 		definition.AddOption(opt)
 	}
 }
 
-// showHelp shows contextual help.
-func (a *Application) showHelp(command *Command, path []string) {
+// findVerbosity finds the verbosity level requested in the given input. Each use of the verbose
+// option increases the verbosity by one level, and the quiet option overrides any verbose options.
+func (a *Application) findVerbosity(input *Input) Verbosity {
+	verbosity := VerbosityNormal
+
+	for _, opt := range input.Options {
+		switch opt.Name {
+		case "q", "quiet":
+			return VerbosityQuiet
+		case "v", "verbose":
+			if verbosity < VerbosityDebug {
+				verbosity++
+			}
+		}
+	}
+
+	return verbosity
+}
+
+// showError shows the given error on the error writer.
+func (a *Application) showError(err error) {
+	a.output.Errorf("<error>%s: %s</error>\n", EscapeMarkup(a.Name), EscapeMarkup(err.Error()))
+}
+
+// showTryHelp shows a hint about how to get help after an error, unless output is quiet.
+func (a *Application) showTryHelp(helpCommand string) {
+	if a.output.IsQuiet() {
+		return
+	}
+
+	a.output.Errorf("Try '%s --help' for more information.\n", helpCommand)
+}
+
+// width finds the width that help for this application should be wrapped to, when it's written to
+// the regular writer.
+func (a *Application) width() int {
+	return a.widthFor(a.Writer)
+}
+
+// widthFor finds the width that help written to the given writer should be wrapped to. The output's
+// settings are used while the application is running.
+func (a *Application) widthFor(writer io.Writer) int {
+	if a.output != nil {
+		return a.output.widthFor(writer)
+	}
+
+	return detectWidth(a.Width, writer, os.Environ())
+}
+
+// environ gets the environment the application is running with, as given to Run. The process's
+// environment is used when it isn't running (e.g. when help is described directly).
+func (a *Application) environ() []string {
+	if a.output != nil {
+		return a.output.env
+	}
+
+	return os.Environ()
+}
+
+// helpRenderer gets the HelpRenderer used to render help for this application.
+func (a *Application) helpRenderer() HelpRenderer {
+	if a.HelpRenderer != nil {
+		return a.HelpRenderer
+	}
+
+	return NewTemplateHelpRenderer()
+}
+
+// showHelp shows contextual help, written to, and wrapped to the width of the given writer.
+func (a *Application) showHelp(writer io.Writer, command *Command, path []string) {
+	width := a.widthFor(writer)
+
 	if command != nil {
-		a.output.Println(DescribeCommand(a, command, path))
+		fmt.Fprintln(writer, describeCommand(a, command, path, width))
 	} else {
-		a.output.Println(DescribeApplication(a))
+		fmt.Fprintln(writer, describeApplication(a, width))
 	}
 }
@@ -1,52 +1,22 @@
 package console
 
 import (
-	"fmt"
-
 	"github.com/seeruk/go-console/parameters"
-	"github.com/seeruk/go-wordwrap"
 )
 
 // DescribeApplication describes an Application to provide usage information.
 func DescribeApplication(app *Application) string {
-	var help string
-
-	if app.Logo != "" {
-		help += fmt.Sprintf("%s\n", app.Logo)
-	}
-
-	help += fmt.Sprintf("%s version %s\n\n", app.Name, app.Version)
-	help += fmt.Sprintf("%s\n", describeApplicationUsage(app))
-
-	options := findApplicationOptions(app)
-
-	if len(options) > 0 {
-		help += fmt.Sprintf("\n%s", parameters.DescribeOptions(options))
-	}
-
-	if len(app.commands) > 0 {
-		help += fmt.Sprintf("\n%s", DescribeCommands(app.commands))
-		help += fmt.Sprintf(
-			"\n  Run `$ %s COMMAND --help` for more information about a command.\n",
-			app.UsageName,
-		)
-	}
-
-	if len(app.Help) > 0 {
-		help += "\nHELP:\n"
-		help += wordwrap.Indent(app.Help, "  ", true) + "\n"
-	}
-
-	return help
+	return describeApplication(app, app.width())
 }
 
-// describeApplicationUsage describes the application's usage, mainly based on whether or not the
-// application has commands and if it does, whether you must run a command for anything to happen.
-func describeApplicationUsage(app *Application) string {
-	desc := "USAGE:\n"
-	desc += fmt.Sprintf("  %s COMMAND [OPTIONS...] [ARGUMENTS...]", app.UsageName)
-
-	return desc
+// describeApplication describes an Application, wrapped to the given width.
+func describeApplication(app *Application, width int) string {
+	return app.helpRenderer().Render(HelpData{
+		App:      app,
+		Width:    width,
+		Options:  findApplicationOptions(app),
+		Commands: app.helpCommands(),
+	})
 }
 
 // findApplicationOptions finds all of the defined options on the given application.
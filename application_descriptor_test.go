@@ -37,8 +37,9 @@ func TestDescribeApplication(t *testing.T) {
 	t.Run("should show the application usage", func(t *testing.T) {
 		application := console.NewApplication("seeruk/go-console", "1.2.3+testing")
 		application.UsageName = "eidolon_console_binary"
+		application.AddCommand(&console.Command{Name: "foo-cmd"})
 
-		usage := application.UsageName + " COMMAND [OPTIONS...] [ARGUMENTS...]"
+		usage := application.UsageName + " [-h] COMMAND"
 
 		result := console.DescribeApplication(application)
 
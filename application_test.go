@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"io/ioutil"
 	"math/rand"
 	"strings"
 	"testing"
@@ -23,6 +24,7 @@ func TestApplication(t *testing.T) {
 	createApplication := func(writer io.Writer) *console.Application {
 		application := console.NewApplication("seeruk/go-console", "1.2.3.+testing")
 		application.Writer = writer
+		application.ErrWriter = ioutil.Discard
 
 		return application
 	}
@@ -107,6 +109,33 @@ func TestApplication(t *testing.T) {
 			assert.True(t, containsHelp, "Expected help output.")
 		})
 
+		t.Run("should wrap help to the width given by the COLUMNS env var", func(t *testing.T) {
+			writer := bytes.Buffer{}
+			application := createApplication(&writer)
+			application.AddCommand(&console.Command{
+				Name:        "test",
+				Description: strings.Repeat("word ", 20),
+			})
+			application.Run([]string{"--help"}, []string{"COLUMNS=60"})
+
+			for _, line := range strings.Split(writer.String(), "\n") {
+				assert.True(t, len(line) <= 60, "Expected line to fit in width: %q", line)
+			}
+		})
+
+		t.Run("should wrap help to the width set on the application", func(t *testing.T) {
+			writer := bytes.Buffer{}
+			application := createApplication(&writer)
+			application.Width = 120
+			application.AddCommand(&console.Command{
+				Name:        "test",
+				Description: strings.Repeat("word ", 20),
+			})
+			application.Run([]string{"--help"}, []string{"COLUMNS=50"})
+
+			assert.Contains(t, writer.String(), strings.TrimSpace(strings.Repeat("word ", 20)))
+		})
+
 		t.Run("should return exit code 0 if a command was found, and ran OK", func(t *testing.T) {
 			var a string
 			var b int
@@ -197,6 +226,54 @@ func TestApplication(t *testing.T) {
 			assert.Equal(t, 1, code)
 		})
 
+		t.Run("should write errors to the error writer", func(t *testing.T) {
+			var a string
+			var b int
+
+			writer := bytes.Buffer{}
+			errWriter := bytes.Buffer{}
+			application := createApplication(&writer)
+			application.ErrWriter = &errWriter
+			application.AddCommand(createTestCommand(&a, &b))
+
+			code := application.Run([]string{"test", "aval", "--int-opt=hello"}, []string{})
+
+			assert.Equal(t, 101, code)
+			assert.Equal(t, "", writer.String())
+			assert.True(t, strings.Contains(errWriter.String(), "Try '"), "Expected error output.")
+		})
+
+		t.Run("should write help to the error writer if no command was found", func(t *testing.T) {
+			writer := bytes.Buffer{}
+			errWriter := bytes.Buffer{}
+			application := createApplication(&writer)
+			application.ErrWriter = &errWriter
+
+			application.Run([]string{"foo"}, []string{})
+
+			assert.Equal(t, "", writer.String())
+			assert.True(t, strings.Contains(errWriter.String(), "USAGE:"), "Expected help output.")
+		})
+
+		t.Run("should make the application reader available to commands", func(t *testing.T) {
+			writer := bytes.Buffer{}
+			application := createApplication(&writer)
+			application.Reader = strings.NewReader("piped input")
+			application.AddCommand(&console.Command{
+				Name: "test",
+				Execute: func(input *console.Input, output *console.Output) error {
+					bs, err := ioutil.ReadAll(input.GetReader())
+					output.Print(string(bs))
+					return err
+				},
+			})
+
+			code := application.Run([]string{"test"}, []string{})
+
+			assert.Equal(t, 0, code)
+			assert.Equal(t, "piped input", writer.String())
+		})
+
 		t.Run("should configure the application definition", func(t *testing.T) {
 			// @TODO: Update with global options implementation.
 			//var a string
@@ -241,6 +318,190 @@ func TestApplication(t *testing.T) {
 		})
 	})
 
+	t.Run("Use()", func(t *testing.T) {
+		t.Run("should wrap command execution in middleware, in the order it is added", func(t *testing.T) {
+			var calls []string
+
+			record := func(name string) console.MiddlewareFunc {
+				return func(next console.ExecuteFunc) console.ExecuteFunc {
+					return func(input *console.Input, output *console.Output) error {
+						calls = append(calls, name+":before")
+						err := next(input, output)
+						calls = append(calls, name+":after")
+						return err
+					}
+				}
+			}
+
+			command := &console.Command{
+				Name: "command",
+				Execute: func(input *console.Input, output *console.Output) error {
+					calls = append(calls, "execute")
+					return nil
+				},
+			}
+
+			command.Use(record("cmd"))
+
+			writer := bytes.Buffer{}
+			application := createApplication(&writer)
+			application.Use(record("app1"), record("app2"))
+			application.AddCommand(command)
+
+			code := application.Run([]string{"command"}, []string{})
+
+			assert.Equal(t, 0, code)
+			assert.Equal(t, []string{
+				"app1:before",
+				"app2:before",
+				"cmd:before",
+				"execute",
+				"cmd:after",
+				"app2:after",
+				"app1:after",
+			}, calls)
+		})
+
+		t.Run("should allow middleware to change the result of execution", func(t *testing.T) {
+			writer := bytes.Buffer{}
+			errWriter := bytes.Buffer{}
+			application := createApplication(&writer)
+			application.ErrWriter = &errWriter
+			application.Use(func(next console.ExecuteFunc) console.ExecuteFunc {
+				return func(input *console.Input, output *console.Output) (err error) {
+					defer func() {
+						if r := recover(); r != nil {
+							err = fmt.Errorf("recovered: %v", r)
+						}
+					}()
+
+					return next(input, output)
+				}
+			})
+
+			application.AddCommand(&console.Command{
+				Name: "test",
+				Execute: func(input *console.Input, output *console.Output) error {
+					panic("oh no")
+				},
+			})
+
+			code := application.Run([]string{"test"}, []string{})
+
+			assert.Equal(t, 1, code)
+			assert.Contains(t, errWriter.String(), "recovered: oh no")
+		})
+	})
+
+	t.Run("PreRun and PostRun", func(t *testing.T) {
+		createHookedApplication := func(writer io.Writer, calls *[]string, execErr error) *console.Application {
+			preRun := func(name string) console.PreRunFunc {
+				return func(input *console.Input, output *console.Output) error {
+					*calls = append(*calls, name+":pre")
+					return nil
+				}
+			}
+
+			postRun := func(name string) console.PostRunFunc {
+				return func(input *console.Input, output *console.Output, err error) error {
+					*calls = append(*calls, fmt.Sprintf("%s:post:%v", name, err))
+					return nil
+				}
+			}
+
+			subCommand := &console.Command{
+				Name:    "sub",
+				PreRun:  preRun("sub"),
+				PostRun: postRun("sub"),
+				Execute: func(input *console.Input, output *console.Output) error {
+					*calls = append(*calls, "execute")
+					return execErr
+				},
+			}
+
+			command := &console.Command{
+				Name:    "command",
+				PreRun:  preRun("command"),
+				PostRun: postRun("command"),
+			}
+
+			command.AddCommand(subCommand)
+
+			application := createApplication(writer)
+			application.PreRun = preRun("app")
+			application.PostRun = postRun("app")
+			application.AddCommand(command)
+
+			return application
+		}
+
+		t.Run("should run hooks in order along the command path", func(t *testing.T) {
+			var calls []string
+
+			writer := bytes.Buffer{}
+			application := createHookedApplication(&writer, &calls, nil)
+
+			code := application.Run([]string{"command", "sub"}, []string{})
+
+			assert.Equal(t, 0, code)
+			assert.Equal(t, []string{
+				"app:pre",
+				"command:pre",
+				"sub:pre",
+				"execute",
+				"sub:post:<nil>",
+				"command:post:<nil>",
+				"app:post:<nil>",
+			}, calls)
+		})
+
+		t.Run("should run post-run hooks with the error if execution fails", func(t *testing.T) {
+			var calls []string
+
+			writer := bytes.Buffer{}
+			application := createHookedApplication(&writer, &calls, errors.New("failed"))
+
+			code := application.Run([]string{"command", "sub"}, []string{})
+
+			assert.Equal(t, 1, code)
+			assert.Contains(t, calls, "sub:post:failed")
+			assert.Contains(t, calls, "app:post:failed")
+		})
+
+		t.Run("should not execute the command if a pre-run hook fails", func(t *testing.T) {
+			var calls []string
+
+			writer := bytes.Buffer{}
+			application := createHookedApplication(&writer, &calls, nil)
+			application.PreRun = func(input *console.Input, output *console.Output) error {
+				return errors.New("pre-run failed")
+			}
+
+			code := application.Run([]string{"command", "sub"}, []string{})
+
+			assert.Equal(t, 1, code)
+			assert.NotContains(t, calls, "execute")
+			assert.Contains(t, calls, "app:post:pre-run failed")
+		})
+
+		t.Run("should use the error returned from a post-run hook", func(t *testing.T) {
+			var calls []string
+
+			writer := bytes.Buffer{}
+			errWriter := bytes.Buffer{}
+			application := createHookedApplication(&writer, &calls, nil)
+			application.ErrWriter = &errWriter
+			application.PostRun = func(input *console.Input, output *console.Output, err error) error {
+				return errors.New("post-run failed")
+			}
+
+			code := application.Run([]string{"command", "sub"}, []string{})
+
+			assert.Equal(t, 1, code)
+			assert.Contains(t, errWriter.String(), "post-run failed")
+		})
+	})
+
 	t.Run("AddCommands()", func(t *testing.T) {
 		t.Run("should work when adding 1 command", func(t *testing.T) {
 			writer := bytes.Buffer{}
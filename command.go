@@ -12,6 +12,19 @@ type ConfigureFunc func(*Definition)
 // ExecuteFunc is a function to perform whatever task this command does.
 type ExecuteFunc func(input *Input, output *Output) error
 
+// MiddlewareFunc is a function that wraps an ExecuteFunc, allowing behaviour to be added before
+// and after the next function in the chain is called (e.g. timing, logging, or panic recovery).
+type MiddlewareFunc func(next ExecuteFunc) ExecuteFunc
+
+// PreRunFunc is a function that is run before a command is executed. Returning an error will stop
+// the command from being executed.
+type PreRunFunc func(input *Input, output *Output) error
+
+// PostRunFunc is a function that is run after a command is executed, even if execution failed. The
+// error returned by execution (if any) is passed in. If a non-nil error is returned it replaces the
+// error that will be reported by the application.
+type PostRunFunc func(input *Input, output *Output, err error) error
+
 // Command represents a command to run in an application.
 type Command struct {
 	// The name of the command.
@@ -22,13 +35,32 @@ type Command struct {
 	Description string
 	// Help message for the command.
 	Help string
+	// The output formats supported by Output.Render for this command, the first is the default. If
+	// empty, DefaultFormats are supported.
+	OutputFormats []string
+	// Prompts for missing required arguments and options when input is interactive.
+	PromptMissing bool
+	// Examples of how to run the command, shown in help, and generated documentation.
+	Examples []Example
+	// Hides the command from help and completion, it can still be run.
+	Hidden bool
 	// Function to configure command-level parameters.
 	Configure ConfigureFunc
 	// Function to execute when this command is requested.
 	Execute ExecuteFunc
+	// Function to run before this command, or any of it's sub-commands are executed.
+	PreRun PreRunFunc
+	// Function to run after this command, or any of it's sub-commands are executed.
+	PostRun PostRunFunc
 
 	// Array of sub-commands. May contain sub-commands.
 	commands []*Command
+	// Middleware to wrap the execution of this command, and any of it's sub-commands.
+	middleware []MiddlewareFunc
+	// The path to the executable for external plugin commands.
+	plugin string
+	// Function to run instead of the usual input handling, given the raw input for the command.
+	raw func(argv []string) int
 }
 
 // AddCommands adds sub-commands to the command.
@@ -49,3 +81,11 @@ func (c *Command) AddCommand(command *Command) *Command {
 func (c *Command) Commands() []*Command {
 	return c.commands
 }
+
+// Use adds middleware to the command. Middleware applies to this command and any of it's
+// sub-commands, and is run in the order it is added.
+func (c *Command) Use(middleware ...MiddlewareFunc) *Command {
+	c.middleware = append(c.middleware, middleware...)
+
+	return c
+}
@@ -11,55 +11,62 @@ import (
 
 // DescribeCommand describes a Command on an Application to provide usage information.
 func DescribeCommand(app *Application, cmd *Command, path []string) string {
-	var help string
-
-	arguments := findCommandArguments(app, cmd)
-	options := findCommandOptions(app, cmd)
-
-	help += fmt.Sprintf("%s\n", describeCommandUsage(app, cmd, arguments, options, path))
-
-	if len(arguments) > 0 {
-		help += fmt.Sprintf("\n%s", parameters.DescribeArguments(arguments))
-	}
-
-	if len(options) > 0 {
-		help += fmt.Sprintf("\n%s", parameters.DescribeOptions(options))
-	}
+	return describeCommand(app, cmd, path, app.width())
+}
 
-	if len(cmd.commands) > 0 {
-		help += fmt.Sprintf("\n%s", DescribeCommands(cmd.commands))
-		help += fmt.Sprintf(
-			"\n  Run `$ %s %s COMMAND --help` for more information about a command.\n",
-			app.UsageName,
-			strings.Join(path, " "),
-		)
-	}
+// describeCommand describes a Command on an Application, wrapped to the given width.
+func describeCommand(app *Application, cmd *Command, path []string, width int) string {
+	commands := cmd.commands
 
-	if len(cmd.Help) > 0 {
-		help += "\nHELP:\n"
-		help += wordwrap.Indent(cmd.Help, "  ", true) + "\n"
+	// The application's commands can be run instead of the root command.
+	if cmd == app.rootCommand && len(path) == 0 {
+		commands = app.helpCommands()
 	}
 
-	return help
+	return app.helpRenderer().Render(HelpData{
+		App:       app,
+		Command:   cmd,
+		Path:      path,
+		Width:     width,
+		Arguments: findCommandArguments(app, cmd),
+		Options:   findCommandOptions(app, cmd),
+		Commands:  commands,
+	})
 }
 
 // DescribeCommands describes an array of Commands to provide usage information.
 func DescribeCommands(commands []*Command) string {
-	desc := "COMMANDS:\n"
+	return DescribeCommandsWidth(commands, DefaultWidth)
+}
+
+// DescribeCommandsWidth describes an array of Commands like DescribeCommands does, wrapping
+// descriptions so that lines are no wider than the given width.
+func DescribeCommandsWidth(commands []*Command, width int) string {
+	return "COMMANDS:\n" + describeCommandList(commands, width)
+}
+
+// describeCommandList describes an array of Commands like DescribeCommandsWidth does, without the
+// "COMMANDS:" heading.
+func describeCommandList(commands []*Command, width int) string {
+	var desc string
 
 	// Create array and map for specific output ordering.
 	cmdKeys := []string{}
 	cmdMap := make(map[string]*Command)
 
-	var width int
+	var column int
 	for _, cmd := range commands {
+		if cmd.Hidden {
+			continue
+		}
+
 		cmdKeys = append(cmdKeys, cmd.Name)
 		cmdMap[cmd.Name] = cmd
 
 		len := len(cmd.Name)
 
-		if len > (width - 2) {
-			width = len + 2
+		if len > (column - 2) {
+			column = len + 2
 		}
 	}
 
@@ -69,7 +76,7 @@ func DescribeCommands(commands []*Command) string {
 		cmd := cmdMap[name]
 
 		// Get space for the right-side of the command name.
-		spacing := width - len(name)
+		spacing := column - len(name)
 
 		cmdDesc := cmd.Description
 		if cmd.Alias != "" {
@@ -77,7 +84,7 @@ func DescribeCommands(commands []*Command) string {
 		}
 
 		// Wrap the description onto new lines if necessary.
-		wrapper := wordwrap.Wrapper(78-width, true)
+		wrapper := wordwrap.Wrapper(parameters.WrapWidth(width, column), true)
 		wrapped := wrapper(cmdDesc)
 
 		// Indent and prefix to produce the result.
@@ -89,42 +96,6 @@ func DescribeCommands(commands []*Command) string {
 	return desc
 }
 
-// describeCommandUsage describes a command's usage.
-func describeCommandUsage(app *Application, cmd *Command, args []parameters.Argument, opts []parameters.Option, path []string) string {
-	desc := "USAGE:\n"
-	desc += fmt.Sprintf("  %s", app.UsageName)
-
-	if len(path) > 0 {
-		desc += fmt.Sprintf(" %s", strings.Join(path, " "))
-	}
-
-	if len(opts) > 0 {
-		desc += " [OPTIONS...]"
-	}
-
-	if len(args) > 0 {
-		for _, arg := range args {
-			lb := ""
-			rb := ""
-
-			if !arg.Required {
-				lb = "["
-				rb = "]"
-			}
-
-			desc += fmt.Sprintf(" %s%s%s", lb, arg.Name, rb)
-		}
-	}
-
-	if cmd.Description != "" {
-		wrapper := wordwrap.Wrapper(78, true)
-
-		desc += "\n\n" + wordwrap.Indent(wrapper(cmd.Description), "  ", true)
-	}
-
-	return desc
-}
-
 // findCommandArguments finds all of the defined arguments on the given application and command.
 func findCommandArguments(app *Application, cmd *Command) []parameters.Argument {
 	definition := buildCommandDefinition(app, cmd)
@@ -162,3 +133,12 @@ func buildCommandDefinition(app *Application, cmd *Command) *Definition {
 
 	return definition
 }
+
+// describeHelpHint describes how to get help for the commands available after the given command
+// line (e.g. the application's usage name, and the path to a command).
+func describeHelpHint(commandLine string, width int) string {
+	wrapper := wordwrap.Wrapper(parameters.WrapWidth(width, 0), false)
+	hint := fmt.Sprintf("Run `$ %s COMMAND --help` for more information about a command.", commandLine)
+
+	return wordwrap.Indent(wrapper(hint), "  ", true)
+}
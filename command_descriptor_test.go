@@ -161,6 +161,76 @@ func TestDescribeCommand(t *testing.T) {
 		assert.True(t, strings.Contains(result, subCommand.Name), "Expected sub-command name")
 		assert.True(t, strings.Contains(result, subCommand.Description), "Expected sub-command desc")
 	})
+
+	t.Run("should annotate options as configured on the application", func(t *testing.T) {
+		var name string
+
+		application := console.NewApplication("seeruk/go-console", "1.2.3+testing")
+
+		command := console.Command{
+			Name: "test-command-name",
+			Configure: func(definition *console.Definition) {
+				definition.AddOption(console.OptionDefinition{
+					Value:    parameters.NewStringValue(&name),
+					Spec:     "--name=NAME",
+					Desc:     "The name.",
+					EnvVar:   "TEST_NAME",
+					Required: true,
+				})
+			},
+		}
+
+		result := console.DescribeCommand(application, &command, []string{command.Name})
+		assert.Contains(t, result, "The name. (required) [env: TEST_NAME]\n")
+
+		application.OptionAnnotations = parameters.AnnotateEnvVar
+
+		result = console.DescribeCommand(application, &command, []string{command.Name})
+		assert.Contains(t, result, "The name. [env: TEST_NAME]\n")
+	})
+
+	t.Run("should order options as configured on the application", func(t *testing.T) {
+		var zone, apple string
+
+		application := console.NewApplication("seeruk/go-console", "1.2.3+testing")
+
+		command := console.Command{
+			Name: "test-command-name",
+			Configure: func(definition *console.Definition) {
+				definition.AddOption(console.OptionDefinition{
+					Value: parameters.NewStringValue(&zone),
+					Spec:  "--zone=ZONE",
+				})
+
+				definition.AddOption(console.OptionDefinition{
+					Value: parameters.NewStringValue(&apple),
+					Spec:  "--apple=APPLE",
+				})
+			},
+		}
+
+		result := console.DescribeCommand(application, &command, []string{command.Name})
+		assert.True(t, strings.Index(result, "--zone=ZONE ") > strings.Index(result, "--apple=APPLE "), "Expected --zone to come after --apple.")
+
+		application.OptionOrder = parameters.OptionOrderDeclaration
+
+		result = console.DescribeCommand(application, &command, []string{command.Name})
+		assert.True(t, strings.Index(result, "--zone=ZONE ") < strings.Index(result, "--apple=APPLE "), "Expected --zone to come before --apple.")
+	})
+
+	t.Run("should show the application's commands for the root command", func(t *testing.T) {
+		application := console.NewApplication("seeruk/go-console", "1.2.3+testing")
+
+		command := console.Command{}
+
+		application.SetRootCommand(&command)
+		application.AddCommand(&console.Command{Name: "test-command-name"})
+
+		result := console.DescribeCommand(application, &command, []string{})
+
+		assert.True(t, strings.Contains(result, "COMMANDS:"), "Expected commands")
+		assert.True(t, strings.Contains(result, "test-command-name"), "Expected command name")
+	})
 }
 
 func TestDescribeCommands(t *testing.T) {
@@ -67,4 +67,16 @@ func TestCommand(t *testing.T) {
 			}
 		})
 	})
+
+	t.Run("Use()", func(t *testing.T) {
+		t.Run("should return the command", func(t *testing.T) {
+			command := &console.Command{}
+
+			result := command.Use(func(next console.ExecuteFunc) console.ExecuteFunc {
+				return next
+			})
+
+			assert.Equal(t, command, result)
+		})
+	})
 }
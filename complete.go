@@ -0,0 +1,217 @@
+package console
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/seeruk/go-console/parameters"
+)
+
+// completeCommandName is the name of the hidden command that completion scripts call to complete
+// values at runtime.
+const completeCommandName = "__complete"
+
+// newCompleteCommand creates the hidden command that completion scripts call back into to find
+// suggestions for the word being completed. It's given the words on the command line after the
+// application's name, the last of which is the (possibly empty) word being completed.
+//
+// Each suggestion is written on it's own line, optionally followed by a tab and a description. The
+// last line is a colon followed by a parameters.CompletionDirective (e.g. ":4"), telling the shell
+// what else to do. When completing the value of an `--option=value` word, suggestions are for the
+// value only.
+func newCompleteCommand(app *Application) *Command {
+	return &Command{
+		Name:        completeCommandName,
+		Description: "Suggest completions for a partial command line.",
+		Hidden:      true,
+		raw: func(argv []string) int {
+			suggestions, directive := app.complete(argv)
+
+			var result strings.Builder
+			for _, suggestion := range suggestions {
+				result.WriteString(suggestion + "\n")
+			}
+
+			fmt.Fprintf(&result, ":%d\n", directive)
+
+			if err := app.output.writeRaw(result.String()); err != nil {
+				return 1
+			}
+
+			return 0
+		},
+	}
+}
+
+// complete finds suggestions for the last of the given words, which is the word being completed.
+// The other words are used to find the command, argument, or option being completed.
+func (a *Application) complete(words []string) ([]string, parameters.CompletionDirective) {
+	if len(words) == 0 {
+		words = []string{""}
+	}
+
+	current := words[len(words)-1]
+	previous := words[:len(words)-1]
+
+	cmd, path := a.resolveCommand(previous, a.environ())
+	definition := buildCommandDefinition(a, cmd)
+
+	commands := a.Commands()
+	if len(path) > 0 {
+		commands = cmd.Commands()
+	}
+
+	// Work out how many arguments have been given, and whether the last word was an option that
+	// is still waiting for it's value.
+	var pending *parameters.Option
+	var position int
+	var optsEnded bool
+
+	for _, word := range previous[len(path):] {
+		switch {
+		case pending != nil:
+			pending = nil
+		case word == "--":
+			optsEnded = true
+		case !optsEnded && strings.HasPrefix(word, "-") && len(word) > 1:
+			pending = findCompletionOption(definition, word)
+		default:
+			position++
+		}
+	}
+
+	if pending != nil {
+		return completeValue(pending.Complete, pending.CompleteDirective, current)
+	}
+
+	if !optsEnded && strings.HasPrefix(current, "-") {
+		if split := strings.SplitN(current, "=", 2); len(split) == 2 {
+			opt, ok := definition.options[strings.TrimLeft(split[0], "-")]
+			if !ok || opt.ValueMode == parameters.OptionValueNone {
+				return nil, parameters.CompleteNoFiles
+			}
+
+			return completeValue(opt.Complete, opt.CompleteDirective, split[1])
+		}
+
+		return completeOptionNames(definition, current)
+	}
+
+	var suggestions []string
+	directive := parameters.CompleteDefault
+
+	if position == 0 {
+		for _, command := range commands {
+			if command.Hidden {
+				continue
+			}
+
+			for _, name := range []string{command.Name, command.Alias} {
+				if name != "" && strings.HasPrefix(name, current) {
+					suggestions = append(suggestions, completionSuggestion(name, command.Description))
+				}
+			}
+		}
+
+		if len(commands) > 0 {
+			directive = parameters.CompleteNoFiles
+		}
+	}
+
+	if arguments := definition.Arguments(); position < len(arguments) {
+		values, argDirective := completeValue(
+			arguments[position].Complete,
+			arguments[position].CompleteDirective,
+			current,
+		)
+
+		suggestions = append(suggestions, values...)
+		directive = argDirective
+	}
+
+	return suggestions, directive
+}
+
+// completeValue finds suggestions for the value of an argument or option. Only suggestions that
+// start with the given prefix are kept, as not every shell filters them.
+func completeValue(fn parameters.CompleteFunc, directive parameters.CompletionDirective, prefix string) ([]string, parameters.CompletionDirective) {
+	if fn == nil {
+		return nil, directive
+	}
+
+	var suggestions []string
+
+	for _, suggestion := range fn(prefix) {
+		value := strings.SplitN(suggestion, "\t", 2)[0]
+
+		if strings.HasPrefix(value, prefix) {
+			suggestions = append(suggestions, suggestion)
+		}
+	}
+
+	return suggestions, directive
+}
+
+// completeOptionNames finds the names of the options in the given definition that start with the
+// given prefix. Long options that require a value are suggested with a trailing `=`.
+func completeOptionNames(definition *Definition, prefix string) ([]string, parameters.CompletionDirective) {
+	var suggestions []string
+	var valued int
+
+	for _, opt := range definition.Options() {
+		for _, name := range opt.Names {
+			flag := optionFlag(name)
+			if len(name) > 1 && opt.ValueMode == parameters.OptionValueRequired {
+				flag += "="
+			}
+
+			if strings.HasPrefix(flag, prefix) {
+				suggestions = append(suggestions, completionSuggestion(flag, opt.Description))
+
+				if strings.HasSuffix(flag, "=") {
+					valued++
+				}
+			}
+		}
+	}
+
+	sort.Strings(suggestions)
+
+	directive := parameters.CompleteNoFiles
+	if len(suggestions) == 1 && valued == 1 {
+		directive |= parameters.CompleteNoSpace
+	}
+
+	return suggestions, directive
+}
+
+// findCompletionOption finds the option that is waiting for a value after the given word, if it
+// requires a value, and it hasn't already been given one in the same word.
+func findCompletionOption(definition *Definition, word string) *parameters.Option {
+	if strings.Contains(word, "=") {
+		return nil
+	}
+
+	name := strings.TrimPrefix(word, "--")
+	if !strings.HasPrefix(word, "--") {
+		// Short options may be grouped (e.g. `-abc`), only the last one may take a value.
+		name = string([]rune(word)[len([]rune(word))-1])
+	}
+
+	opt, ok := definition.options[name]
+	if !ok || opt.ValueMode != parameters.OptionValueRequired {
+		return nil
+	}
+
+	return &opt
+}
+
+// completionSuggestion formats a suggestion, with the first line of it's description, if it has one.
+func completionSuggestion(value string, description string) string {
+	if description = firstLine(description); description != "" {
+		return value + "\t" + description
+	}
+
+	return value
+}
@@ -0,0 +1,175 @@
+package console_test
+
+import (
+	"bytes"
+	"io/ioutil"
+	"strings"
+	"testing"
+
+	"github.com/seeruk/go-console"
+	"github.com/seeruk/go-console/parameters"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestComplete(t *testing.T) {
+	createApplication := func(writer *bytes.Buffer) *console.Application {
+		var dsn, env, level string
+		var steps int
+
+		migrate := &console.Command{
+			Name:        "migrate",
+			Alias:       "m",
+			Description: "Run database migrations.",
+			Configure: func(definition *console.Definition) {
+				definition.AddOption(console.OptionDefinition{
+					Value: parameters.NewStringValue(&dsn),
+					Spec:  "-d, --dsn=DSN",
+					Desc:  "The database DSN.",
+					Complete: func(prefix string) []string {
+						var suggestions []string
+						for _, dsn := range []string{"prod\tProduction", "staging\tStaging"} {
+							if strings.HasPrefix(dsn, prefix) {
+								suggestions = append(suggestions, dsn)
+							}
+						}
+
+						return suggestions
+					},
+					CompleteDirective: parameters.CompleteNoFiles,
+				})
+
+				definition.AddOption(console.OptionDefinition{
+					Value:             parameters.NewStringValue(&env),
+					Spec:              "--env-file=PATH",
+					CompleteDirective: parameters.CompleteFiles,
+				})
+
+				definition.AddOption(console.OptionDefinition{
+					Value:   parameters.NewStringValue(&level),
+					Spec:    "--log-level=LEVEL",
+					Choices: []string{"debug", "info", "warn"},
+				})
+
+				definition.AddArgument(console.ArgumentDefinition{
+					Value:             parameters.NewIntValue(&steps),
+					Spec:              "[STEPS]",
+					CompleteDirective: parameters.CompleteDirectories,
+				})
+			},
+			Execute: func(input *console.Input, output *console.Output) error {
+				return nil
+			},
+		}
+
+		db := &console.Command{
+			Name:        "db",
+			Description: "Manage the database.",
+		}
+
+		db.AddCommand(migrate)
+		db.AddCommand(&console.Command{
+			Name:   "secret",
+			Hidden: true,
+		})
+
+		application := console.NewApplication("seeruk/go-console", "1.2.3+testing")
+		application.UsageName = "app"
+		application.Writer = writer
+		application.ErrWriter = ioutil.Discard
+		application.EnableCompletion = true
+		application.EnableColorOption = true
+		application.EnableHelpCommand = true
+		application.AddCommand(db)
+
+		return application
+	}
+
+	complete := func(args ...string) string {
+		writer := bytes.Buffer{}
+
+		code := createApplication(&writer).Run(append([]string{"__complete"}, args...), []string{})
+		if code != 0 {
+			t.Fatalf("unexpected exit code %d", code)
+		}
+
+		return writer.String()
+	}
+
+	t.Run("should suggest commands with descriptions", func(t *testing.T) {
+		assert.Equal(t, "db\tManage the database.\n:2\n", complete("d"))
+		assert.Equal(t, "migrate\tRun database migrations.\nm\tRun database migrations.\n:2\n", complete("db", ""))
+	})
+
+	t.Run("should not suggest hidden commands", func(t *testing.T) {
+		assert.Equal(t, ":2\n", complete("db", "s"))
+	})
+
+	t.Run("should suggest option names", func(t *testing.T) {
+		assert.Equal(t, "--dsn=\tThe database DSN.\n:3\n", complete("db", "migrate", "--d"))
+		assert.Equal(t, "--help\tDisplay contextual help?\n:2\n", complete("db", "m", "--h"))
+	})
+
+	t.Run("should suggest option values after the option", func(t *testing.T) {
+		assert.Equal(t, "prod\tProduction\nstaging\tStaging\n:2\n", complete("db", "m", "--dsn", ""))
+		assert.Equal(t, "staging\tStaging\n:2\n", complete("db", "m", "-d", "s"))
+	})
+
+	t.Run("should suggest option values in the same word", func(t *testing.T) {
+		assert.Equal(t, "prod\tProduction\n:2\n", complete("db", "m", "--dsn=p"))
+	})
+
+	t.Run("should suggest the choices of options", func(t *testing.T) {
+		assert.Equal(t, "debug\ninfo\nwarn\n:2\n", complete("db", "m", "--log-level", ""))
+		assert.Equal(t, "info\n:2\n", complete("db", "m", "--log-level=i"))
+	})
+
+	t.Run("should only suggest values that start with the prefix", func(t *testing.T) {
+		assert.Equal(t, "never\n:2\n", complete("--color=n"))
+		assert.Equal(t, "auto\nalways\n:2\n", complete("--color", "a"))
+	})
+
+	t.Run("should suggest command names for the help command", func(t *testing.T) {
+		assert.Equal(t, "db\tManage the database.\n:2\n", complete("help", "d"))
+	})
+
+	t.Run("should return the directive for options without suggestions", func(t *testing.T) {
+		assert.Equal(t, ":4\n", complete("db", "m", "--env-file", ""))
+	})
+
+	t.Run("should return the directive for arguments", func(t *testing.T) {
+		assert.Equal(t, ":8\n", complete("db", "m", "--dsn", "prod", ""))
+	})
+
+	t.Run("should fall back to the default directive past the last argument", func(t *testing.T) {
+		assert.Equal(t, ":0\n", complete("db", "m", "1", ""))
+	})
+
+	t.Run("should complete even if completion is not enabled", func(t *testing.T) {
+		var ran bool
+
+		writer := bytes.Buffer{}
+
+		application := createApplication(&writer)
+		application.EnableCompletion = false
+		application.SetRootCommand(&console.Command{
+			Execute: func(input *console.Input, output *console.Output) error {
+				ran = true
+				return nil
+			},
+		})
+
+		code := application.Run([]string{"__complete", "d"}, []string{})
+
+		assert.Equal(t, 0, code)
+		assert.False(t, ran, "Expected the root command not to run.")
+		assert.Equal(t, "db\tManage the database.\n:2\n", writer.String())
+	})
+
+	t.Run("should not show hidden commands in help", func(t *testing.T) {
+		writer := bytes.Buffer{}
+
+		createApplication(&writer).Run([]string{"--help"}, []string{})
+
+		assert.NotContains(t, writer.String(), "__complete")
+	})
+}
@@ -0,0 +1,651 @@
+package console
+
+import (
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+
+	"github.com/seeruk/go-console/parameters"
+)
+
+// Shells that completion scripts can be generated for.
+const (
+	ShellBash = "bash"
+	ShellZsh  = "zsh"
+	ShellFish = "fish"
+)
+
+// CompletionShells contains all of the shells that completion scripts can be generated for.
+var CompletionShells = []string{ShellBash, ShellZsh, ShellFish}
+
+// GenerateCompletion generates a completion script for the given shell, covering all of the
+// application's commands (including sub-commands and aliases), and their options. Scripts complete
+// values by running the application's hidden `__complete` command, so they must be used with a
+// binary that runs the application with Application.Run. The command is available whether or not
+// EnableCompletion is set.
+func GenerateCompletion(app *Application, shell string) (string, error) {
+	var builder strings.Builder
+
+	if err := WriteCompletion(&builder, app, shell); err != nil {
+		return "", err
+	}
+
+	return builder.String(), nil
+}
+
+// WriteCompletion writes a completion script for the given shell to the given writer. See
+// GenerateCompletion for more information.
+func WriteCompletion(writer io.Writer, app *Application, shell string) error {
+	root := buildCompletionTree(app)
+
+	var script string
+
+	switch shell {
+	case ShellBash:
+		script = generateBashCompletion(app.UsageName, root)
+	case ShellZsh:
+		script = generateZshCompletion(app.UsageName, root)
+	case ShellFish:
+		script = generateFishCompletion(app.UsageName, root)
+	default:
+		return fmt.Errorf(
+			"unsupported shell '%s', expected one of: %s",
+			shell,
+			strings.Join(CompletionShells, ", "),
+		)
+	}
+
+	_, err := io.WriteString(writer, script)
+
+	return err
+}
+
+// completionNode is a command (or the application itself) that completions are generated for.
+type completionNode struct {
+	// The key used to identify this node in scripts, the usage name followed by the command path.
+	key string
+	// The names this command can be run with (i.e. it's name, and alias).
+	names []string
+	// The first line of the command's description.
+	description string
+	// The options available when running this command.
+	options []parameters.Option
+	// The arguments this command accepts.
+	arguments []parameters.Argument
+	// The sub-commands of this command.
+	children []*completionNode
+}
+
+// walk calls the given function for this node, and then for each node below it.
+func (n *completionNode) walk(fn func(node *completionNode)) {
+	fn(n)
+
+	for _, child := range n.children {
+		child.walk(fn)
+	}
+}
+
+// buildCompletionTree builds a tree of completionNodes for all of the commands on the given
+// application, starting with the application itself.
+func buildCompletionTree(app *Application) *completionNode {
+	var build func(key string, cmd *Command, commands []*Command) *completionNode
+
+	build = func(key string, cmd *Command, commands []*Command) *completionNode {
+		definition := buildCommandDefinition(app, cmd)
+
+		node := &completionNode{
+			key:       key,
+			options:   definition.Options(),
+			arguments: definition.Arguments(),
+		}
+
+		for _, child := range commands {
+			if child.Hidden {
+				continue
+			}
+
+			childNode := build(key+" "+child.Name, child, child.Commands())
+			childNode.names = []string{child.Name}
+			childNode.description = firstLine(child.Description)
+
+			if child.Alias != "" {
+				childNode.names = append(childNode.names, child.Alias)
+			}
+
+			node.children = append(node.children, childNode)
+		}
+
+		return node
+	}
+
+	return build(app.UsageName, app.rootCommand, app.Commands())
+}
+
+// generateBashCompletion generates a completion script for bash.
+func generateBashCompletion(name string, root *completionNode) string {
+	var script strings.Builder
+
+	fn := "_" + completionIdentifier(name) + "_completions"
+	dynamic := "__" + completionIdentifier(name) + "_complete"
+
+	fmt.Fprintf(&script, "# bash completion for %s\n", name)
+	fmt.Fprintf(&script, "#\n")
+	fmt.Fprintf(&script, "# To load completions in the current shell, run:\n")
+	fmt.Fprintf(&script, "#   source <(%s completion bash)\n\n", name)
+	fmt.Fprintf(&script, "%s\n", dynamicCompletion(bashDynamicCompletion, dynamic))
+	fmt.Fprintf(&script, "%s() {\n", fn)
+	fmt.Fprintf(&script, "    local cur prev cmd word i commands flags valued\n")
+	fmt.Fprintf(&script, "    cur=\"${COMP_WORDS[COMP_CWORD]}\"\n")
+	fmt.Fprintf(&script, "    prev=\"${COMP_WORDS[COMP_CWORD-1]}\"\n")
+	fmt.Fprintf(&script, "    cmd=%s\n\n", shellQuote(root.key))
+
+	// Find the command being completed by walking the words before the cursor.
+	fmt.Fprintf(&script, "    for ((i = 1; i < COMP_CWORD; i++)); do\n")
+	fmt.Fprintf(&script, "        word=\"${COMP_WORDS[i]}\"\n")
+	fmt.Fprintf(&script, "        case \"${cmd}/${word}\" in\n")
+
+	root.walk(func(node *completionNode) {
+		for _, child := range node.children {
+			var patterns []string
+			for _, name := range child.names {
+				patterns = append(patterns, shellQuote(node.key+"/"+name))
+			}
+
+			fmt.Fprintf(
+				&script,
+				"            %s) cmd=%s ;;\n",
+				strings.Join(patterns, "|"),
+				shellQuote(child.key),
+			)
+		}
+	})
+
+	fmt.Fprintf(&script, "        esac\n")
+	fmt.Fprintf(&script, "    done\n\n")
+
+	// Find the commands and options available for the command being completed.
+	fmt.Fprintf(&script, "    case \"${cmd}\" in\n")
+
+	root.walk(func(node *completionNode) {
+		var commands, flags, valued []string
+
+		for _, child := range node.children {
+			commands = append(commands, child.names...)
+		}
+
+		for _, opt := range node.options {
+			for _, name := range opt.Names {
+				flag := optionFlag(name)
+
+				if opt.ValueMode == parameters.OptionValueRequired {
+					valued = append(valued, flag)
+
+					if len(name) > 1 {
+						flag += "="
+					}
+				}
+
+				flags = append(flags, flag)
+			}
+		}
+
+		fmt.Fprintf(&script, "        %s)\n", shellQuote(node.key))
+		fmt.Fprintf(&script, "            commands=%s\n", shellQuote(strings.Join(commands, " ")))
+		fmt.Fprintf(&script, "            flags=%s\n", shellQuote(strings.Join(flags, " ")))
+		fmt.Fprintf(&script, "            valued=%s\n", shellQuote(strings.Join(valued, " ")))
+		fmt.Fprintf(&script, "            ;;\n")
+	})
+
+	fmt.Fprintf(&script, "    esac\n\n")
+
+	// Bash splits `--option=value` into separate words, so the `=` is skipped over.
+	fmt.Fprintf(&script, "    if [[ \"${cur}\" == \"=\" ]]; then\n")
+	fmt.Fprintf(&script, "        cur=''\n")
+	fmt.Fprintf(&script, "    elif [[ \"${prev}\" == \"=\" ]]; then\n")
+	fmt.Fprintf(&script, "        prev=\"${COMP_WORDS[COMP_CWORD-2]}\"\n")
+	fmt.Fprintf(&script, "    fi\n\n")
+	fmt.Fprintf(&script, "    if [[ \" ${valued} \" == *\" ${prev} \"* ]]; then\n")
+	fmt.Fprintf(&script, "        %s \"${cur}\"\n", dynamic)
+	fmt.Fprintf(&script, "    elif [[ -z \"${commands}\" && \"${cur}\" != -* ]]; then\n")
+	fmt.Fprintf(&script, "        %s \"${cur}\"\n", dynamic)
+	fmt.Fprintf(&script, "    elif [[ \"${cur}\" == -* ]]; then\n")
+	fmt.Fprintf(&script, "        COMPREPLY=($(compgen -W \"${flags}\" -- \"${cur}\"))\n\n")
+	fmt.Fprintf(&script, "        if [[ ${#COMPREPLY[@]} -eq 1 && \"${COMPREPLY[0]}\" == *= ]]; then\n")
+	fmt.Fprintf(&script, "            compopt -o nospace\n")
+	fmt.Fprintf(&script, "        fi\n")
+	fmt.Fprintf(&script, "    else\n")
+	fmt.Fprintf(&script, "        COMPREPLY=($(compgen -W \"${commands}\" -- \"${cur}\"))\n")
+	fmt.Fprintf(&script, "    fi\n")
+	fmt.Fprintf(&script, "}\n\n")
+	fmt.Fprintf(&script, "complete -o default -F %s %s\n", fn, shellQuote(name))
+
+	return script.String()
+}
+
+// generateZshCompletion generates a completion script for zsh.
+func generateZshCompletion(name string, root *completionNode) string {
+	var script strings.Builder
+
+	dynamic := "__" + completionIdentifier(name) + "_complete"
+	functions := make(map[*completionNode]string)
+
+	root.walk(func(node *completionNode) {
+		functions[node] = "_" + completionIdentifier(node.key)
+	})
+
+	fmt.Fprintf(&script, "#compdef %s\n\n", name)
+	fmt.Fprintf(&script, "# zsh completion for %s\n", name)
+	fmt.Fprintf(&script, "#\n")
+	fmt.Fprintf(&script, "# To load completions in the current shell, run:\n")
+	fmt.Fprintf(&script, "#   source <(%s completion zsh)\n\n", name)
+	fmt.Fprintf(&script, "%s", dynamicCompletion(zshDynamicCompletion, dynamic))
+
+	root.walk(func(node *completionNode) {
+		var specs []string
+
+		for _, opt := range node.options {
+			specs = append(specs, zshOptionSpecs(opt, dynamic)...)
+		}
+
+		fmt.Fprintf(&script, "\n%s() {\n", functions[node])
+
+		if len(node.children) == 0 {
+			for i, arg := range node.arguments {
+				colons := ":"
+				if !arg.Required {
+					colons = "::"
+				}
+
+				spec := fmt.Sprintf("%d%s%s:%s", i+1, colons, zshEscape(arg.Name, ":"), dynamic)
+				specs = append(specs, shellQuote(spec))
+			}
+
+			if len(node.arguments) == 0 {
+				specs = append(specs, shellQuote("*:: :_default"))
+			}
+
+			fmt.Fprintf(&script, "    _arguments -s \\\n        %s\n", strings.Join(specs, " \\\n        "))
+			fmt.Fprintf(&script, "}\n")
+
+			return
+		}
+
+		specs = append(specs, shellQuote("1: :->command"), shellQuote("*:: :->args"))
+
+		fmt.Fprintf(&script, "    local context state state_descr line\n")
+		fmt.Fprintf(&script, "    typeset -A opt_args\n\n")
+		fmt.Fprintf(&script, "    _arguments -s -C \\\n        %s\n\n", strings.Join(specs, " \\\n        "))
+		fmt.Fprintf(&script, "    case \"${state}\" in\n")
+		fmt.Fprintf(&script, "        command)\n")
+		fmt.Fprintf(&script, "            local -a commands\n")
+		fmt.Fprintf(&script, "            commands=(\n")
+
+		for _, child := range node.children {
+			for _, name := range child.names {
+				entry := zshEscape(name, ":") + ":" + child.description
+				fmt.Fprintf(&script, "                %s\n", shellQuote(entry))
+			}
+		}
+
+		fmt.Fprintf(&script, "            )\n")
+		fmt.Fprintf(&script, "            _describe -t commands 'command' commands\n")
+		fmt.Fprintf(&script, "            ;;\n")
+		fmt.Fprintf(&script, "        args)\n")
+		fmt.Fprintf(&script, "            case \"${words[1]}\" in\n")
+
+		for _, child := range node.children {
+			var patterns []string
+			for _, name := range child.names {
+				patterns = append(patterns, shellQuote(name))
+			}
+
+			fmt.Fprintf(&script, "                %s) %s ;;\n", strings.Join(patterns, "|"), functions[child])
+		}
+
+		fmt.Fprintf(&script, "            esac\n")
+		fmt.Fprintf(&script, "            ;;\n")
+		fmt.Fprintf(&script, "    esac\n")
+		fmt.Fprintf(&script, "}\n")
+	})
+
+	fmt.Fprintf(&script, "\nif [[ \"${funcstack[1]}\" == %s ]]; then\n", functions[root])
+	fmt.Fprintf(&script, "    %s \"$@\"\n", functions[root])
+	fmt.Fprintf(&script, "else\n")
+	fmt.Fprintf(&script, "    compdef %s %s\n", functions[root], shellQuote(name))
+	fmt.Fprintf(&script, "fi\n")
+
+	return script.String()
+}
+
+// zshOptionSpecs creates the _arguments specs for each of the names of the given option.
+func zshOptionSpecs(opt parameters.Option, dynamic string) []string {
+	var flags []string
+	for _, name := range opt.Names {
+		flags = append(flags, optionFlag(name))
+	}
+
+	var specs []string
+
+	description := zshEscape(firstLine(opt.Description), "[]:")
+
+	for _, flag := range flags {
+		spec := flag
+		if len(flags) > 1 {
+			spec = "(" + strings.Join(flags, " ") + ")" + flag
+		}
+
+		long := strings.HasPrefix(flag, "--")
+
+		switch opt.ValueMode {
+		case parameters.OptionValueRequired:
+			if long {
+				spec += "="
+			} else {
+				spec += "+"
+			}
+
+			spec += fmt.Sprintf("[%s]:%s:%s", description, zshEscape(opt.ValueName, ":"), dynamic)
+		case parameters.OptionValueOptional:
+			if long {
+				spec += "=-"
+			} else {
+				spec += "-"
+			}
+
+			spec += fmt.Sprintf("[%s]::%s:%s", description, zshEscape(opt.ValueName, ":"), dynamic)
+		default:
+			spec += fmt.Sprintf("[%s]", description)
+		}
+
+		specs = append(specs, shellQuote(spec))
+	}
+
+	return specs
+}
+
+// zshEscape escapes the given characters (and backslashes) in the given text with backslashes.
+func zshEscape(text string, chars string) string {
+	var escaped strings.Builder
+
+	for _, r := range text {
+		if r == '\\' || strings.ContainsRune(chars, r) {
+			escaped.WriteRune('\\')
+		}
+
+		escaped.WriteRune(r)
+	}
+
+	return escaped.String()
+}
+
+// generateFishCompletion generates a completion script for fish.
+func generateFishCompletion(name string, root *completionNode) string {
+	var script strings.Builder
+
+	ident := completionIdentifier(name)
+	pathFn := "__" + ident + "_command_path"
+	atFn := "__" + ident + "_at"
+	dynamic := "__" + ident + "_complete"
+
+	fmt.Fprintf(&script, "# fish completion for %s\n", name)
+	fmt.Fprintf(&script, "#\n")
+	fmt.Fprintf(&script, "# To load completions in the current shell, run:\n")
+	fmt.Fprintf(&script, "#   %s completion fish | source\n\n", name)
+	fmt.Fprintf(&script, "%s\n", dynamicCompletion(fishDynamicCompletion, dynamic))
+
+	// Find the command being completed by walking the words before the cursor.
+	fmt.Fprintf(&script, "function %s\n", pathFn)
+	fmt.Fprintf(&script, "    set -l path %s\n", fishQuote(root.key))
+	fmt.Fprintf(&script, "    for token in (commandline -opc)[2..-1]\n")
+	fmt.Fprintf(&script, "        switch \"$path/$token\"\n")
+
+	root.walk(func(node *completionNode) {
+		for _, child := range node.children {
+			var patterns []string
+			for _, name := range child.names {
+				patterns = append(patterns, fishQuote(node.key+"/"+name))
+			}
+
+			fmt.Fprintf(&script, "            case %s\n", strings.Join(patterns, " "))
+			fmt.Fprintf(&script, "                set path %s\n", fishQuote(child.key))
+		}
+	})
+
+	fmt.Fprintf(&script, "        end\n")
+	fmt.Fprintf(&script, "    end\n")
+	fmt.Fprintf(&script, "    echo $path\n")
+	fmt.Fprintf(&script, "end\n\n")
+
+	fmt.Fprintf(&script, "function %s\n", atFn)
+	fmt.Fprintf(&script, "    test (%s) = \"$argv\"\n", pathFn)
+	fmt.Fprintf(&script, "end\n")
+
+	root.walk(func(node *completionNode) {
+		condition := fishQuote(atFn + " " + node.key)
+		prefix := fmt.Sprintf("complete -c %s -n %s", fishQuote(name), condition)
+
+		fmt.Fprintf(&script, "\n")
+
+		if len(node.children) > 0 {
+			fmt.Fprintf(&script, "%s -f\n", prefix)
+		} else {
+			fmt.Fprintf(&script, "%s -f -a %s\n", prefix, fishQuote("("+dynamic+")"))
+		}
+
+		for _, child := range node.children {
+			for _, name := range child.names {
+				fmt.Fprintf(&script, "%s -f -a %s", prefix, fishQuote(name))
+
+				if child.description != "" {
+					fmt.Fprintf(&script, " -d %s", fishQuote(child.description))
+				}
+
+				fmt.Fprintf(&script, "\n")
+			}
+		}
+
+		for _, opt := range node.options {
+			fmt.Fprintf(&script, "%s", prefix)
+
+			for _, name := range opt.Names {
+				if len(name) > 1 {
+					fmt.Fprintf(&script, " -l %s", fishQuote(name))
+				} else {
+					fmt.Fprintf(&script, " -s %s", fishQuote(name))
+				}
+			}
+
+			if opt.ValueMode == parameters.OptionValueRequired {
+				fmt.Fprintf(&script, " -r -f -a %s", fishQuote("("+dynamic+")"))
+			}
+
+			if description := firstLine(opt.Description); description != "" {
+				fmt.Fprintf(&script, " -d %s", fishQuote(description))
+			}
+
+			fmt.Fprintf(&script, "\n")
+		}
+	})
+
+	return script.String()
+}
+
+// dynamicCompletion creates the shell function that calls back into the application to complete
+// values at runtime (see newCompleteCommand), from the given template.
+func dynamicCompletion(template string, function string) string {
+	return strings.Replace(template, "{{function}}", function, -1)
+}
+
+// bashDynamicCompletion is the template for the bash function that completes values at runtime.
+// The word being completed is given as it's only argument, as bash may have split it at an `=`.
+const bashDynamicCompletion = `{{function}}() {
+    local line="${COMP_LINE:0:COMP_POINT}" cur="$1" out directive value
+    local -a args
+
+    read -r -a args <<< "${line}"
+
+    if [[ "${line}" == *[[:space:]] ]]; then
+        args+=('')
+    fi
+
+    out="$("${args[0]}" __complete "${args[@]:1}" 2>/dev/null)" || return
+    directive="${out##*:}"
+
+    while IFS= read -r value; do
+        value="${value%%$'\t'*}"
+
+        if [[ -n "${value}" && "${value}" != :* && "${value}" == "${cur}"* ]]; then
+            COMPREPLY+=("${value}")
+        fi
+    done <<< "${out}"
+
+    if (( directive & 8 )); then
+        COMPREPLY+=($(compgen -d -- "${cur}"))
+    elif (( directive & 4 )); then
+        COMPREPLY+=($(compgen -f -- "${cur}"))
+    fi
+
+    if (( directive & 1 )); then
+        compopt -o nospace
+    fi
+
+    if (( directive & 2 )); then
+        compopt +o default
+    fi
+}
+`
+
+// zshDynamicCompletion is the template for the zsh function that completes values at runtime.
+const zshDynamicCompletion = `{{function}}() {
+    local -a args lines suggestions
+    local out directive line value
+
+    args=("${(z)LBUFFER}")
+
+    if [[ "${LBUFFER}" == *' ' ]]; then
+        args+=('')
+    fi
+
+    out="$("${args[1]}" __complete "${(@)args[2,-1]}" 2>/dev/null)" || return 1
+    lines=("${(@f)out}")
+    directive="${lines[-1]#:}"
+
+    for line in "${(@)lines[1,-2]}"; do
+        value="${${line%%$'\t'*}//:/\\:}"
+
+        if [[ "${line}" == *$'\t'* ]]; then
+            suggestions+=("${value}:${line#*$'\t'}")
+        else
+            suggestions+=("${value}")
+        fi
+    done
+
+    if (( directive & 8 )); then
+        _files -/
+    elif (( directive & 4 )); then
+        _files
+    fi
+
+    if (( ${#suggestions} && directive & 1 )); then
+        _describe -t values 'value' suggestions -S ''
+    elif (( ${#suggestions} )); then
+        _describe -t values 'value' suggestions
+    elif (( ! (directive & 14) )); then
+        _files
+    fi
+}
+`
+
+// fishDynamicCompletion is the template for the fish function that completes values at runtime.
+const fishDynamicCompletion = `function {{function}}
+    set -l tokens (commandline -opc)
+    set -l current (commandline -ct)
+    set -l lines (command $tokens[1] __complete $tokens[2..-1] "$current" 2>/dev/null)
+    or return
+
+    set -l directive (string replace ':' '' -- $lines[-1])
+    set -e lines[-1]
+
+    printf '%s\n' $lines
+
+    if test (math "bitand($directive, 8)") -ne 0
+        __fish_complete_directories "$current"
+    else if test (math "bitand($directive, 4)") -ne 0
+        __fish_complete_path "$current"
+    else if test (count $lines) -eq 0; and test (math "bitand($directive, 2)") -eq 0
+        __fish_complete_path "$current"
+    end
+end
+`
+
+// optionFlag converts an option name into a flag as it would be typed (e.g. "-v", or "--verbose").
+func optionFlag(name string) string {
+	if len(name) > 1 {
+		return "--" + name
+	}
+
+	return "-" + name
+}
+
+// shellQuote quotes the given text for use in a POSIX-like shell (bash, or zsh).
+func shellQuote(text string) string {
+	return "'" + strings.Replace(text, "'", `'\''`, -1) + "'"
+}
+
+// fishQuote quotes the given text for use in fish.
+func fishQuote(text string) string {
+	replacer := strings.NewReplacer(`\`, `\\`, `'`, `\'`)
+
+	return "'" + replacer.Replace(text) + "'"
+}
+
+// nonIdentifierChars matches characters that can't be used in shell function names.
+var nonIdentifierChars = regexp.MustCompile(`[^A-Za-z0-9_]+`)
+
+// completionIdentifier converts the given text into something that can be used as (part of) a
+// shell function name.
+func completionIdentifier(text string) string {
+	return nonIdentifierChars.ReplaceAllString(text, "_")
+}
+
+// firstLine returns the first line of the given text, with surrounding whitespace removed.
+func firstLine(text string) string {
+	return strings.TrimSpace(strings.SplitN(strings.TrimSpace(text), "\n", 2)[0])
+}
+
+// newCompletionCommand creates the built-in completion command for the given application.
+func newCompletionCommand(app *Application) *Command {
+	var shell string
+
+	return &Command{
+		Name:        "completion",
+		Description: "Generate a shell completion script.",
+		Help: fmt.Sprintf(
+			"To load completions in the current shell, run one of:\n\n"+
+				"  $ source <(%[1]s completion bash)\n"+
+				"  $ source <(%[1]s completion zsh)\n"+
+				"  $ %[1]s completion fish | source\n\n"+
+				"To load completions for every new shell, add the same line to your shell's\n"+
+				"startup file (e.g. ~/.bashrc, ~/.zshrc, or ~/.config/fish/config.fish).",
+			app.UsageName,
+		),
+		Configure: func(definition *Definition) {
+			definition.AddArgument(ArgumentDefinition{
+				Value: parameters.NewStringValue(&shell),
+				Spec:  "SHELL",
+				Desc: fmt.Sprintf(
+					"The shell to generate a script for, one of: %s.",
+					strings.Join(CompletionShells, ", "),
+				),
+				Complete: func(prefix string) []string {
+					return CompletionShells
+				},
+				CompleteDirective: parameters.CompleteNoFiles,
+			})
+		},
+		Execute: func(input *Input, output *Output) error {
+			return WriteCompletion(output.Writer, app, shell)
+		},
+	}
+}
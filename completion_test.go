@@ -0,0 +1,112 @@
+package console_test
+
+import (
+	"bytes"
+	"io/ioutil"
+	"testing"
+
+	"github.com/seeruk/go-console"
+	"github.com/seeruk/go-console/parameters"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGenerateCompletion(t *testing.T) {
+	createApplication := func() *console.Application {
+		var dsn string
+		var steps int
+
+		migrate := &console.Command{
+			Name:        "migrate",
+			Alias:       "m",
+			Description: "Run database migrations.",
+			Configure: func(definition *console.Definition) {
+				definition.AddOption(console.OptionDefinition{
+					Value: parameters.NewStringValue(&dsn),
+					Spec:  "-d, --dsn=DSN",
+					Desc:  "The database DSN.",
+				})
+
+				definition.AddArgument(console.ArgumentDefinition{
+					Value: parameters.NewIntValue(&steps),
+					Spec:  "[STEPS]",
+				})
+			},
+			Execute: func(input *console.Input, output *console.Output) error {
+				return nil
+			},
+		}
+
+		db := &console.Command{
+			Name:        "db",
+			Description: "Manage the database.",
+		}
+
+		db.AddCommand(migrate)
+
+		application := console.NewApplication("seeruk/go-console", "1.2.3+testing")
+		application.UsageName = "app"
+		application.AddCommand(db)
+
+		return application
+	}
+
+	for _, shell := range console.CompletionShells {
+		t.Run("should include commands and options for "+shell, func(t *testing.T) {
+			script, err := console.GenerateCompletion(createApplication(), shell)
+
+			assert.NoError(t, err)
+			assert.Contains(t, script, "db")
+			assert.Contains(t, script, "migrate")
+			assert.Contains(t, script, "dsn")
+			assert.Contains(t, script, "help")
+		})
+	}
+
+	t.Run("should mark options that take values for bash", func(t *testing.T) {
+		script, err := console.GenerateCompletion(createApplication(), console.ShellBash)
+
+		assert.NoError(t, err)
+		assert.Contains(t, script, "flags='-h --help -d --dsn='")
+		assert.Contains(t, script, "valued='-d --dsn'")
+		assert.Contains(t, script, "'app db/migrate'|'app db/m') cmd='app db migrate' ;;")
+		assert.Contains(t, script, "complete -o default -F _app_completions 'app'")
+	})
+
+	t.Run("should mark options that take values for zsh", func(t *testing.T) {
+		script, err := console.GenerateCompletion(createApplication(), console.ShellZsh)
+
+		assert.NoError(t, err)
+		assert.Contains(t, script, "#compdef app")
+		assert.Contains(t, script, "'(-d --dsn)--dsn=[The database DSN.]:DSN:__app_complete'")
+		assert.Contains(t, script, "'(-h --help)-h[Display contextual help?]'")
+		assert.Contains(t, script, "'migrate:Run database migrations.'")
+	})
+
+	t.Run("should mark options that take values for fish", func(t *testing.T) {
+		script, err := console.GenerateCompletion(createApplication(), console.ShellFish)
+
+		assert.NoError(t, err)
+		assert.Contains(t, script, "-n '__app_at app db migrate' -s 'd' -l 'dsn' -r")
+		assert.Contains(t, script, "-n '__app_at app db' -f -a 'm' -d 'Run database migrations.'")
+	})
+
+	t.Run("should error for unsupported shells", func(t *testing.T) {
+		_, err := console.GenerateCompletion(createApplication(), "powershell")
+
+		assert.EqualError(t, err, "unsupported shell 'powershell', expected one of: bash, zsh, fish")
+	})
+
+	t.Run("should be available as a built-in command", func(t *testing.T) {
+		writer := bytes.Buffer{}
+
+		application := createApplication()
+		application.Writer = &writer
+		application.ErrWriter = ioutil.Discard
+		application.EnableCompletion = true
+
+		code := application.Run([]string{"completion", "bash"}, []string{})
+
+		assert.Equal(t, 0, code)
+		assert.Contains(t, writer.String(), "'app/completion') cmd='app completion' ;;")
+	})
+}
@@ -2,6 +2,7 @@ package console
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/seeruk/go-console/parameters"
 	"github.com/seeruk/go-console/specification"
@@ -35,6 +36,12 @@ type ArgumentDefinition struct {
 	Spec string
 	// The description of the argument.
 	Desc string
+	// Is the value of the argument secret (i.e. it should not be shown when entered)?
+	Secret bool
+	// Function to suggest values for the argument, when completing input in a shell.
+	Complete parameters.CompleteFunc
+	// How a shell should complete the value of the argument (e.g. as a file, or directory).
+	CompleteDirective parameters.CompletionDirective
 }
 
 // OptionDefinition is a struct that represents the entire configuration of a CLI option.
@@ -47,6 +54,20 @@ type OptionDefinition struct {
 	Desc string
 	// The name of an environment variable to read an option value from.
 	EnvVar string
+	// The name of the group the option is shown in, in help (e.g. "Connection options"). Options
+	// without a group are shown first.
+	Group string
+	// The values the option accepts. If given, other values are rejected, and these values are
+	// suggested when completing input in a shell (unless Complete is set).
+	Choices []string
+	// Is the option required? Required options must be given in the input, or environment.
+	Required bool
+	// Is the value of the option secret (i.e. it should not be shown when entered)?
+	Secret bool
+	// Function to suggest values for the option, when completing input in a shell.
+	Complete parameters.CompleteFunc
+	// How a shell should complete the value of the option (e.g. as a file, or directory).
+	CompleteDirective parameters.CompletionDirective
 }
 
 // Arguments gets all of the arguments in this Definition.
@@ -76,6 +97,10 @@ func (d *Definition) AddArgument(definition ArgumentDefinition) {
 
 	arg.Description = definition.Desc
 	arg.Value = definition.Value
+	arg.Default = parameters.DefaultValue(definition.Value)
+	arg.Secret = definition.Secret
+	arg.Complete = definition.Complete
+	arg.CompleteDirective = definition.CompleteDirective
 
 	if _, ok := d.arguments[arg.Name]; ok {
 		panic(fmt.Errorf("console: Cannot redeclare argument with name '%s'", arg.Name))
@@ -96,7 +121,29 @@ func (d *Definition) AddOption(definition OptionDefinition) {
 
 	opt.Description = definition.Desc
 	opt.EnvVar = definition.EnvVar
+	opt.Group = definition.Group
 	opt.Value = definition.Value
+	opt.Default = parameters.DefaultValue(definition.Value)
+	opt.Choices = definition.Choices
+	opt.Required = definition.Required
+	opt.Secret = definition.Secret
+	opt.Complete = definition.Complete
+	opt.CompleteDirective = definition.CompleteDirective
+
+	if opt.Complete == nil && len(opt.Choices) > 0 {
+		opt.Complete = func(prefix string) []string {
+			var suggestions []string
+			for _, choice := range definition.Choices {
+				if strings.HasPrefix(choice, prefix) {
+					suggestions = append(suggestions, choice)
+				}
+			}
+
+			return suggestions
+		}
+
+		opt.CompleteDirective |= parameters.CompleteNoFiles
+	}
 
 	for _, name := range opt.Names {
 		if _, ok := d.options[name]; ok {
@@ -0,0 +1,98 @@
+package console
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/seeruk/go-console/parameters"
+)
+
+// AddStruct adds arguments and options to the Definition based on the tagged fields of the given
+// pointer to a struct. This is an alternative to calling AddArgument and AddOption for each field.
+//
+// The following field tags are supported:
+//
+//	console:  The parameter specification. Options start with a hyphen (e.g. "-n, --name=NAME"),
+//	          anything else is an argument (e.g. "[NAME]"). Fields without this tag are ignored.
+//	desc:     The description of the parameter.
+//	env:      The name of an environment variable to read an option value from.
+//	default:  A default value, set on the field when the definition is configured.
+//	required: Set to "true" if an option is required (arguments use their specification).
+//	secret:   Set to "true" if the value is secret, so it isn't shown when prompted for.
+//	group:    On a nested struct, the name of the group it's options are shown in, in help (e.g.
+//	          "Connection options").
+//
+// The parameters.Value used for each field is chosen based on the field's type (see
+// parameters.NewValue). Nested structs without a console tag are treated as groups of parameters,
+// and their fields are added to the Definition too. Options in nested structs without a group tag
+// are in the same group as the struct they're nested in.
+func (d *Definition) AddStruct(v interface{}) {
+	rv := reflect.ValueOf(v)
+
+	if rv.Kind() != reflect.Ptr || rv.Elem().Kind() != reflect.Struct {
+		panic(fmt.Errorf("console: Expected pointer to struct, found '%T'", v))
+	}
+
+	d.addStruct(rv.Elem(), "")
+}
+
+// addStruct adds arguments and options for each tagged field in the given struct value. Options are
+// added to the given group.
+func (d *Definition) addStruct(rv reflect.Value, group string) {
+	rt := rv.Type()
+
+	for i := 0; i < rt.NumField(); i++ {
+		field := rt.Field(i)
+		fieldValue := rv.Field(i)
+
+		// Unexported fields can't be set, so they're ignored.
+		if field.PkgPath != "" {
+			continue
+		}
+
+		spec, ok := field.Tag.Lookup("console")
+		if !ok {
+			if field.Type.Kind() == reflect.Struct {
+				structGroup := group
+				if g, ok := field.Tag.Lookup("group"); ok {
+					structGroup = g
+				}
+
+				d.addStruct(fieldValue, structGroup)
+			}
+
+			continue
+		}
+
+		value, err := parameters.NewValue(fieldValue.Addr().Interface())
+		if err != nil {
+			panic(fmt.Errorf("console: Error creating value for field '%s': '%s'", field.Name, err.Error()))
+		}
+
+		if def, ok := field.Tag.Lookup("default"); ok {
+			if err := value.Set(def); err != nil {
+				panic(fmt.Errorf("console: Invalid default value '%s' for field '%s': '%s'", def, field.Name, err.Error()))
+			}
+		}
+
+		if strings.HasPrefix(strings.TrimSpace(spec), "-") {
+			d.AddOption(OptionDefinition{
+				Value:    value,
+				Spec:     spec,
+				Desc:     field.Tag.Get("desc"),
+				EnvVar:   field.Tag.Get("env"),
+				Group:    group,
+				Required: field.Tag.Get("required") == "true",
+				Secret:   field.Tag.Get("secret") == "true",
+			})
+		} else {
+			d.AddArgument(ArgumentDefinition{
+				Value:  value,
+				Spec:   spec,
+				Desc:   field.Tag.Get("desc"),
+				Secret: field.Tag.Get("secret") == "true",
+			})
+		}
+	}
+}
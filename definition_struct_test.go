@@ -0,0 +1,115 @@
+package console_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/seeruk/go-console"
+	"github.com/seeruk/go-console/parameters"
+	"github.com/stretchr/testify/assert"
+)
+
+type testStructConnection struct {
+	Host    string        `console:"--host=HOST" desc:"The host to connect to." default:"localhost"`
+	Timeout time.Duration `console:"--timeout=TIMEOUT" desc:"Connection timeout." default:"5s"`
+}
+
+type testStructDefinition struct {
+	Name       string               `console:"-n, --name=NAME" desc:"Provide a name." env:"NAME" default:"World"`
+	Verbose    bool                 `console:"-v, --verbose" desc:"Enable verbose mode?"`
+	Number     int                  `console:"NUMBER" desc:"Provide a number."`
+	Extra      string               `console:"[EXTRA]"`
+	Connection testStructConnection `group:"Connection options"`
+	Ignored    string
+	ignored    string
+}
+
+func TestDefinition_AddStruct(t *testing.T) {
+	t.Run("should add options from tagged fields", func(t *testing.T) {
+		definition := console.NewDefinition()
+		definition.AddStruct(&testStructDefinition{})
+
+		options := definition.Options()
+
+		assert.Len(t, options, 4)
+		assert.Equal(t, []string{"n", "name"}, options[0].Names)
+		assert.Equal(t, "Provide a name.", options[0].Description)
+		assert.Equal(t, "NAME", options[0].EnvVar)
+		assert.Equal(t, parameters.OptionValueRequired, options[0].ValueMode)
+		assert.Equal(t, parameters.OptionValueNone, options[1].ValueMode)
+	})
+
+	t.Run("should add options in nested structs to their group", func(t *testing.T) {
+		definition := console.NewDefinition()
+		definition.AddStruct(&testStructDefinition{})
+
+		options := definition.Options()
+
+		assert.Equal(t, "", options[0].Group)
+		assert.Equal(t, "Connection options", options[2].Group)
+		assert.Equal(t, "Connection options", options[3].Group)
+	})
+
+	t.Run("should add arguments from tagged fields", func(t *testing.T) {
+		definition := console.NewDefinition()
+		definition.AddStruct(&testStructDefinition{})
+
+		arguments := definition.Arguments()
+
+		assert.Len(t, arguments, 2)
+		assert.Equal(t, "NUMBER", arguments[0].Name)
+		assert.Equal(t, "Provide a number.", arguments[0].Description)
+		assert.True(t, arguments[0].Required)
+		assert.False(t, arguments[1].Required)
+	})
+
+	t.Run("should set default values", func(t *testing.T) {
+		var opts testStructDefinition
+
+		definition := console.NewDefinition()
+		definition.AddStruct(&opts)
+
+		assert.Equal(t, "World", opts.Name)
+		assert.Equal(t, "localhost", opts.Connection.Host)
+		assert.Equal(t, 5*time.Second, opts.Connection.Timeout)
+	})
+
+	t.Run("should map input onto the struct fields", func(t *testing.T) {
+		var opts testStructDefinition
+
+		definition := console.NewDefinition()
+		definition.AddStruct(&opts)
+
+		input := console.ParseInput(definition, []string{"-v", "--host", "example.com", "42"})
+
+		err := console.MapInput("test", definition, input, []string{"NAME=Console"})
+
+		assert.NoError(t, err)
+		assert.Equal(t, "Console", opts.Name)
+		assert.True(t, opts.Verbose)
+		assert.Equal(t, 42, opts.Number)
+		assert.Equal(t, "example.com", opts.Connection.Host)
+	})
+
+	t.Run("should panic if not given a pointer to a struct", func(t *testing.T) {
+		assert.Panics(t, func() {
+			console.NewDefinition().AddStruct(testStructDefinition{})
+		})
+	})
+
+	t.Run("should panic if a field has an unsupported type", func(t *testing.T) {
+		assert.Panics(t, func() {
+			console.NewDefinition().AddStruct(&struct {
+				Value complex64 `console:"--value=VALUE"`
+			}{})
+		})
+	})
+
+	t.Run("should panic if a default value is invalid", func(t *testing.T) {
+		assert.Panics(t, func() {
+			console.NewDefinition().AddStruct(&struct {
+				Value int `console:"--value=VALUE" default:"foo"`
+			}{})
+		})
+	})
+}
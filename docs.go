@@ -0,0 +1,444 @@
+package console
+
+import (
+	"fmt"
+	"html"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/seeruk/go-console/parameters"
+)
+
+// Formats that reference documentation can be generated in.
+const (
+	DocsFormatMarkdown = "markdown"
+	DocsFormatHTML     = "html"
+)
+
+// DocsPage is a page of generated reference documentation, for the application, or a command.
+type DocsPage struct {
+	// The name of the page, the application's usage name followed by the command path, separated
+	// by underscores (e.g. "app_db_migrate").
+	Name string
+	// The name of the file the page is written to (e.g. "app_db_migrate.md").
+	FileName string
+	// The title of the page, the command line used to run the command (e.g. "app db migrate").
+	Title string
+	// The first line of the command's description.
+	Description string
+	// The path to the command from the application, empty for the application itself.
+	Path []string
+	// The content of the page, including any front matter. Empty when passed to hooks.
+	Content string
+}
+
+// DocsGenerator generates reference documentation for an Application, one page per command, in
+// Markdown or HTML. Pages include usage, arguments, options (with environment variables and
+// default values), and links to sub-commands, and can be prefixed with front matter for use with a
+// static site generator.
+type DocsGenerator struct {
+	// The format to generate, DocsFormatMarkdown (the default), or DocsFormatHTML.
+	Format string
+	// Function to produce front matter for a page (e.g. YAML between `---` lines), which is added
+	// to the very top of the page. If nil, no front matter is added.
+	FrontMatter func(page DocsPage) string
+	// Function to produce the link to a page from another page. If nil, the file name is used.
+	Link func(page DocsPage) string
+
+	app *Application
+}
+
+// NewDocsGenerator creates a new DocsGenerator for the given Application, generating Markdown.
+func NewDocsGenerator(app *Application) *DocsGenerator {
+	return &DocsGenerator{
+		Format: DocsFormatMarkdown,
+		app:    app,
+	}
+}
+
+// Generate generates a page for the application, and for each of it's commands (including
+// sub-commands). Hidden commands are skipped.
+func (g *DocsGenerator) Generate() ([]DocsPage, error) {
+	if g.Format != DocsFormatMarkdown && g.Format != DocsFormatHTML {
+		return nil, fmt.Errorf(
+			"unsupported docs format '%s', expected one of: %s, %s",
+			g.Format,
+			DocsFormatMarkdown,
+			DocsFormatHTML,
+		)
+	}
+
+	pages := []DocsPage{g.describe(nil, nil, g.app.Commands())}
+
+	var walk func(path []string, commands []*Command)
+
+	walk = func(path []string, commands []*Command) {
+		for _, cmd := range commands {
+			if cmd.Hidden {
+				continue
+			}
+
+			cmdPath := append(append([]string{}, path...), cmd.Name)
+
+			pages = append(pages, g.describe(cmd, cmdPath, cmd.Commands()))
+			walk(cmdPath, cmd.Commands())
+		}
+	}
+
+	walk(nil, g.app.Commands())
+
+	return pages, nil
+}
+
+// Write generates pages (see Generate), and writes them to files in the given directory, creating
+// it if it doesn't exist.
+func (g *DocsGenerator) Write(dir string) error {
+	pages, err := g.Generate()
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return err
+	}
+
+	for _, page := range pages {
+		err := ioutil.WriteFile(filepath.Join(dir, page.FileName), []byte(page.Content), 0644)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// docsLink is a link from one page to another.
+type docsLink struct {
+	text        string
+	description string
+	href        string
+}
+
+// page creates a DocsPage, without any content, for the given command (or the application itself,
+// if the command is nil).
+func (g *DocsGenerator) page(cmd *Command, path []string) DocsPage {
+	commandLine := append([]string{g.app.UsageName}, path...)
+	name := strings.Join(commandLine, "_")
+
+	extension := ".md"
+	if g.Format == DocsFormatHTML {
+		extension = ".html"
+	}
+
+	description := g.app.Name
+	if cmd != nil {
+		description = firstLine(cmd.Description)
+	}
+
+	return DocsPage{
+		Name:        name,
+		FileName:    name + extension,
+		Title:       strings.Join(commandLine, " "),
+		Description: description,
+		Path:        path,
+	}
+}
+
+// link creates a link to the page for the given command.
+func (g *DocsGenerator) link(cmd *Command, path []string) docsLink {
+	page := g.page(cmd, path)
+
+	href := page.FileName
+	if g.Link != nil {
+		href = g.Link(page)
+	}
+
+	return docsLink{
+		text:        page.Title,
+		description: page.Description,
+		href:        href,
+	}
+}
+
+// describe generates the page for the given command (or the application itself, if the command
+// is nil). The commands given are those that can be run after this one.
+func (g *DocsGenerator) describe(cmd *Command, path []string, commands []*Command) DocsPage {
+	page := g.page(cmd, path)
+
+	definition := buildCommandDefinition(g.app, cmd)
+	arguments := definition.Arguments()
+	groups := parameters.GroupOptions(definition.Options(), g.app.OptionOrder)
+	usage := synopsisString(describeSynopsis(g.app, cmd, path, arguments, definition.Options(), commands, DefaultWidth))
+
+	description := g.app.Help
+	examples := commandExamples(g.app, cmd)
+
+	if cmd != nil {
+		description = strings.TrimSpace(cmd.Description + "\n\n" + cmd.Help)
+	} else if g.app.rootCommand != nil {
+		description = strings.TrimSpace(g.app.rootCommand.Description + "\n\n" + g.app.Help)
+	}
+
+	var links []docsLink
+	for _, sub := range commands {
+		if !sub.Hidden {
+			links = append(links, g.link(sub, append(append([]string{}, path...), sub.Name)))
+		}
+	}
+
+	var parent *docsLink
+	if len(path) > 0 {
+		// The parent's description isn't shown, so there's no need to find the parent command.
+		link := g.link(nil, path[:len(path)-1])
+		parent = &link
+	}
+
+	var content string
+	if g.FrontMatter != nil {
+		content = g.FrontMatter(page)
+	}
+
+	if g.Format == DocsFormatHTML {
+		content += describeDocsHTML(g.app, page, description, usage, arguments, groups, examples, links, parent)
+	} else {
+		content += describeDocsMarkdown(g.app, page, description, usage, arguments, groups, examples, links, parent)
+	}
+
+	page.Content = content
+
+	return page
+}
+
+// describeDocsMarkdown describes a command as a Markdown page.
+func describeDocsMarkdown(app *Application, page DocsPage, description string, usage string, args []parameters.Argument, groups []parameters.OptionGroup, examples []Example, links []docsLink, parent *docsLink) string {
+	var doc strings.Builder
+
+	fmt.Fprintf(&doc, "# %s\n\n", page.Title)
+
+	if description != "" {
+		fmt.Fprintf(&doc, "%s\n\n", stripMarkup(description))
+	}
+
+	fmt.Fprintf(&doc, "## Usage\n\n```\n%s\n```\n", usage)
+
+	if len(args) > 0 {
+		doc.WriteString("\n## Arguments\n\n")
+		doc.WriteString("| Argument | Required | Description |\n")
+		doc.WriteString("| --- | --- | --- |\n")
+
+		for _, arg := range args {
+			fmt.Fprintf(
+				&doc,
+				"| `%s` | %s | %s |\n",
+				arg.Name,
+				docsYesNo(arg.Required),
+				markdownCell(arg.Description),
+			)
+		}
+	}
+
+	if len(groups) > 0 {
+		doc.WriteString("\n## Options\n")
+
+		for _, group := range groups {
+			if group.Name != "" {
+				fmt.Fprintf(&doc, "\n### %s\n", group.Name)
+			}
+
+			doc.WriteString("\n| Option | Environment variable | Default | Description |\n")
+			doc.WriteString("| --- | --- | --- | --- |\n")
+
+			for _, opt := range group.Options {
+				fmt.Fprintf(
+					&doc,
+					"| `%s` | %s | %s | %s |\n",
+					parameters.DescribeOptionKey(opt, nil),
+					markdownCode(opt.EnvVar),
+					markdownCode(optionDefault(opt)),
+					markdownCell(opt.Description),
+				)
+			}
+		}
+	}
+
+	if len(links) > 0 {
+		doc.WriteString("\n## Commands\n\n")
+
+		for _, link := range links {
+			fmt.Fprintf(&doc, "* [%s](%s)", link.text, link.href)
+
+			if link.description != "" {
+				fmt.Fprintf(&doc, " - %s", stripMarkup(link.description))
+			}
+
+			doc.WriteString("\n")
+		}
+	}
+
+	if len(examples) > 0 {
+		doc.WriteString("\n## Examples\n")
+
+		for _, example := range examples {
+			if example.Description != "" {
+				fmt.Fprintf(&doc, "\n%s\n", stripMarkup(example.Description))
+			}
+
+			fmt.Fprintf(&doc, "\n```\n$ %s\n```\n", example.CommandLine(app.UsageName))
+		}
+	}
+
+	if parent != nil {
+		doc.WriteString("\n## See also\n\n")
+		fmt.Fprintf(&doc, "* [%s](%s)\n", parent.text, parent.href)
+	}
+
+	return doc.String()
+}
+
+// describeDocsHTML describes a command as an HTML page.
+func describeDocsHTML(app *Application, page DocsPage, description string, usage string, args []parameters.Argument, groups []parameters.OptionGroup, examples []Example, links []docsLink, parent *docsLink) string {
+	var doc strings.Builder
+
+	esc := html.EscapeString
+
+	fmt.Fprintf(&doc, "<h1>%s</h1>\n", esc(page.Title))
+
+	if description != "" {
+		for _, paragraph := range strings.Split(stripMarkup(description), "\n\n") {
+			fmt.Fprintf(&doc, "<p>%s</p>\n", esc(paragraph))
+		}
+	}
+
+	fmt.Fprintf(&doc, "<h2>Usage</h2>\n<pre><code>%s</code></pre>\n", esc(usage))
+
+	if len(args) > 0 {
+		doc.WriteString("<h2>Arguments</h2>\n<table>\n")
+		doc.WriteString("<tr><th>Argument</th><th>Required</th><th>Description</th></tr>\n")
+
+		for _, arg := range args {
+			fmt.Fprintf(
+				&doc,
+				"<tr><td><code>%s</code></td><td>%s</td><td>%s</td></tr>\n",
+				esc(arg.Name),
+				docsYesNo(arg.Required),
+				esc(stripMarkup(arg.Description)),
+			)
+		}
+
+		doc.WriteString("</table>\n")
+	}
+
+	if len(groups) > 0 {
+		doc.WriteString("<h2>Options</h2>\n")
+
+		for _, group := range groups {
+			if group.Name != "" {
+				fmt.Fprintf(&doc, "<h3>%s</h3>\n", esc(group.Name))
+			}
+
+			doc.WriteString("<table>\n")
+			doc.WriteString("<tr><th>Option</th><th>Environment variable</th><th>Default</th>")
+			doc.WriteString("<th>Description</th></tr>\n")
+
+			for _, opt := range group.Options {
+				fmt.Fprintf(
+					&doc,
+					"<tr><td><code>%s</code></td><td>%s</td><td>%s</td><td>%s</td></tr>\n",
+					esc(parameters.DescribeOptionKey(opt, nil)),
+					htmlCode(opt.EnvVar),
+					htmlCode(optionDefault(opt)),
+					esc(stripMarkup(opt.Description)),
+				)
+			}
+
+			doc.WriteString("</table>\n")
+		}
+	}
+
+	if len(links) > 0 {
+		doc.WriteString("<h2>Commands</h2>\n<ul>\n")
+
+		for _, link := range links {
+			fmt.Fprintf(&doc, "<li><a href=\"%s\">%s</a>", esc(link.href), esc(link.text))
+
+			if link.description != "" {
+				fmt.Fprintf(&doc, " - %s", esc(stripMarkup(link.description)))
+			}
+
+			doc.WriteString("</li>\n")
+		}
+
+		doc.WriteString("</ul>\n")
+	}
+
+	if len(examples) > 0 {
+		doc.WriteString("<h2>Examples</h2>\n")
+
+		for _, example := range examples {
+			if example.Description != "" {
+				fmt.Fprintf(&doc, "<p>%s</p>\n", esc(stripMarkup(example.Description)))
+			}
+
+			fmt.Fprintf(&doc, "<pre><code>$ %s</code></pre>\n", esc(example.CommandLine(app.UsageName)))
+		}
+	}
+
+	if parent != nil {
+		doc.WriteString("<h2>See also</h2>\n<ul>\n")
+		fmt.Fprintf(&doc, "<li><a href=\"%s\">%s</a></li>\n", esc(parent.href), esc(parent.text))
+		doc.WriteString("</ul>\n")
+	}
+
+	return doc.String()
+}
+
+// optionDefault gets the default value of an option, to be shown in documentation. The values of
+// secret options are never shown.
+func optionDefault(opt parameters.Option) string {
+	if opt.Secret {
+		return ""
+	}
+
+	return opt.Default
+}
+
+// docsYesNo describes a boolean as "Yes", or "No".
+func docsYesNo(b bool) string {
+	if b {
+		return "Yes"
+	}
+
+	return "No"
+}
+
+// markdownCell escapes the given text so that it can be used in a Markdown table cell.
+func markdownCell(text string) string {
+	text = strings.Join(strings.Fields(stripMarkup(text)), " ")
+
+	return strings.Replace(text, "|", `\|`, -1)
+}
+
+// markdownCode formats the given text as inline code, if it's not empty.
+func markdownCode(text string) string {
+	if text == "" {
+		return ""
+	}
+
+	return "`" + strings.Replace(text, "|", `\|`, -1) + "`"
+}
+
+// htmlCode formats the given text as inline code, if it's not empty.
+func htmlCode(text string) string {
+	if text == "" {
+		return ""
+	}
+
+	return "<code>" + html.EscapeString(text) + "</code>"
+}
+
+// stripMarkup removes markup tags from the given text.
+func stripMarkup(text string) string {
+	return NewFormatter().Format(text, false)
+}
@@ -0,0 +1,230 @@
+package console_test
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/seeruk/go-console"
+	"github.com/seeruk/go-console/parameters"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDocsGenerator(t *testing.T) {
+	createApplication := func() *console.Application {
+		dsn := "localhost"
+		token := "hunter2"
+
+		var steps int
+
+		migrate := &console.Command{
+			Name:        "migrate",
+			Alias:       "m",
+			Description: "Run database migrations.",
+			Help:        "Migrations are run in <info>order</info>.",
+			Configure: func(definition *console.Definition) {
+				definition.AddOption(console.OptionDefinition{
+					Value:  parameters.NewStringValue(&dsn),
+					Spec:   "-d, --dsn=DSN",
+					Desc:   "The database DSN.",
+					EnvVar: "APP_DSN",
+				})
+
+				definition.AddOption(console.OptionDefinition{
+					Value:  parameters.NewStringValue(&token),
+					Spec:   "--token=TOKEN",
+					Desc:   "An API token.",
+					Secret: true,
+				})
+
+				definition.AddArgument(console.ArgumentDefinition{
+					Value: parameters.NewIntValue(&steps),
+					Spec:  "[STEPS]",
+					Desc:  "The number of migrations to run.",
+				})
+			},
+			Execute: func(input *console.Input, output *console.Output) error {
+				return nil
+			},
+		}
+
+		db := &console.Command{
+			Name:        "db",
+			Description: "Manage the database.",
+		}
+
+		db.AddCommand(migrate)
+		db.AddCommand(&console.Command{Name: "secret", Hidden: true})
+
+		application := console.NewApplication("App", "1.2.3")
+		application.UsageName = "app"
+		application.Help = "App does things."
+		application.AddCommand(db)
+
+		return application
+	}
+
+	findPage := func(pages []console.DocsPage, name string) console.DocsPage {
+		for _, page := range pages {
+			if page.Name == name {
+				return page
+			}
+		}
+
+		t.Fatalf("page '%s' not found", name)
+
+		return console.DocsPage{}
+	}
+
+	t.Run("should generate a page for the application and each command", func(t *testing.T) {
+		pages, err := console.NewDocsGenerator(createApplication()).Generate()
+		assert.NoError(t, err)
+
+		var names []string
+		for _, page := range pages {
+			names = append(names, page.FileName)
+		}
+
+		assert.Equal(t, []string{"app.md", "app_db.md", "app_db_migrate.md"}, names)
+	})
+
+	t.Run("should describe commands in Markdown", func(t *testing.T) {
+		pages, err := console.NewDocsGenerator(createApplication()).Generate()
+		assert.NoError(t, err)
+
+		page := findPage(pages, "app_db_migrate")
+
+		assert.Equal(t, "app db migrate", page.Title)
+		assert.Contains(t, page.Content, "# app db migrate\n\nRun database migrations.\n\nMigrations are run in order.\n")
+		assert.Contains(t, page.Content, "## Usage\n\n```\napp db migrate [-h] [--dsn=DSN] [--token=TOKEN] [STEPS]\n```\n")
+		assert.Contains(t, page.Content, "| `STEPS` | No | The number of migrations to run. |\n")
+		assert.Contains(t, page.Content, "| `-d, --dsn=DSN` | `APP_DSN` | `localhost` | The database DSN. |\n")
+		assert.Contains(t, page.Content, "## See also\n\n* [app db](app_db.md)\n")
+	})
+
+	t.Run("should order and group options as help does", func(t *testing.T) {
+		var zone, apple, host string
+
+		application := console.NewApplication("App", "1.2.3")
+		application.UsageName = "app"
+		application.OptionOrder = parameters.OptionOrderDeclaration
+		application.AddCommand(&console.Command{
+			Name: "serve",
+			Configure: func(definition *console.Definition) {
+				definition.AddOption(console.OptionDefinition{
+					Value: parameters.NewStringValue(&host),
+					Spec:  "--host=HOST",
+					Desc:  "The host.",
+					Group: "Connection options",
+				})
+
+				definition.AddOption(console.OptionDefinition{
+					Value: parameters.NewStringValue(&zone),
+					Spec:  "--zone=ZONE",
+					Desc:  "The zone.",
+				})
+
+				definition.AddOption(console.OptionDefinition{
+					Value: parameters.NewStringValue(&apple),
+					Spec:  "--apple=APPLE",
+					Desc:  "The apple.",
+				})
+			},
+			Execute: func(input *console.Input, output *console.Output) error {
+				return nil
+			},
+		})
+
+		pages, err := console.NewDocsGenerator(application).Generate()
+		assert.NoError(t, err)
+
+		page := findPage(pages, "app_serve")
+
+		assert.True(t, strings.Index(page.Content, "--zone") < strings.Index(page.Content, "--apple"), "Expected --zone to come before --apple.")
+		assert.Contains(t, page.Content, "### Connection options\n\n| Option | Environment variable | Default | Description |\n| --- | --- | --- | --- |\n| `--host=HOST` |  |  | The host. |\n")
+		assert.True(t, strings.Index(page.Content, "--apple") < strings.Index(page.Content, "### Connection options"), "Expected grouped options to come last.")
+	})
+
+	t.Run("should not show the default values of secret options", func(t *testing.T) {
+		pages, err := console.NewDocsGenerator(createApplication()).Generate()
+		assert.NoError(t, err)
+
+		page := findPage(pages, "app_db_migrate")
+
+		assert.Contains(t, page.Content, "| `--token=TOKEN` |  |  | An API token. |\n")
+		assert.NotContains(t, page.Content, "hunter2")
+	})
+
+	t.Run("should link to sub-commands", func(t *testing.T) {
+		pages, err := console.NewDocsGenerator(createApplication()).Generate()
+		assert.NoError(t, err)
+
+		page := findPage(pages, "app_db")
+
+		assert.Contains(t, page.Content, "## Commands\n\n* [app db migrate](app_db_migrate.md) - Run database migrations.\n")
+		assert.NotContains(t, page.Content, "secret")
+	})
+
+	t.Run("should use hooks for front matter and links", func(t *testing.T) {
+		generator := console.NewDocsGenerator(createApplication())
+		generator.FrontMatter = func(page console.DocsPage) string {
+			return "---\ntitle: " + page.Title + "\n---\n\n"
+		}
+
+		generator.Link = func(page console.DocsPage) string {
+			return "/cli/" + page.Name + "/"
+		}
+
+		pages, err := generator.Generate()
+		assert.NoError(t, err)
+
+		page := findPage(pages, "app")
+
+		assert.Contains(t, page.Content, "---\ntitle: app\n---\n\n# app\n")
+		assert.Contains(t, page.Content, "* [app db](/cli/app_db/) - Manage the database.\n")
+	})
+
+	t.Run("should describe commands in HTML", func(t *testing.T) {
+		generator := console.NewDocsGenerator(createApplication())
+		generator.Format = console.DocsFormatHTML
+
+		pages, err := generator.Generate()
+		assert.NoError(t, err)
+
+		page := findPage(pages, "app_db_migrate")
+
+		assert.Equal(t, "app_db_migrate.html", page.FileName)
+		assert.Contains(t, page.Content, "<h1>app db migrate</h1>\n")
+		assert.Contains(t, page.Content, "<pre><code>app db migrate [-h] [--dsn=DSN] [--token=TOKEN] [STEPS]</code></pre>\n")
+		assert.Contains(
+			t,
+			page.Content,
+			"<tr><td><code>-d, --dsn=DSN</code></td><td><code>APP_DSN</code></td><td><code>localhost</code></td>",
+		)
+		assert.Contains(t, page.Content, "<li><a href=\"app_db.html\">app db</a></li>\n")
+	})
+
+	t.Run("should error for unsupported formats", func(t *testing.T) {
+		generator := console.NewDocsGenerator(createApplication())
+		generator.Format = "pdf"
+
+		_, err := generator.Generate()
+		assert.EqualError(t, err, "unsupported docs format 'pdf', expected one of: markdown, html")
+	})
+
+	t.Run("should write pages to files", func(t *testing.T) {
+		dir, err := ioutil.TempDir("", "go-console-docs")
+		assert.NoError(t, err)
+
+		defer os.RemoveAll(dir)
+
+		err = console.NewDocsGenerator(createApplication()).Write(dir)
+		assert.NoError(t, err)
+
+		content, err := ioutil.ReadFile(filepath.Join(dir, "app_db_migrate.md"))
+		assert.NoError(t, err)
+		assert.Contains(t, string(content), "# app db migrate\n")
+	})
+}
@@ -9,6 +9,10 @@ import (
 
 func main() {
 	application := console.NewApplication("seeruk/go-console", "0.1.0")
+	application.EnableVersion = true
+	application.EnableHelpCommand = true
+	application.EnableColorOption = true
+	application.EnableCompletion = true
 	application.Logo = `
                                              #
                               ###            ##
@@ -58,8 +62,12 @@ func main() {
 				Desc:  "Provide your favourite number.",
 			})
 		},
+		Examples: []console.Example{
+			{Args: []string{"greet", "7"}, Description: "Greet the world."},
+			{Args: []string{"greet", "--name=Elliot", "42"}, Description: "Greet Elliot."},
+		},
 		Execute: func(input *console.Input, output *console.Output) error {
-			output.Printf("Hello, %s!\n", name)
+			output.Styledf("Hello, <info>%s</info>!\n", console.EscapeMarkup(name))
 			output.Printf("Your favourite number is %d.\n", favNum)
 			output.Printf("Is isVerbose mode enabled? %t\n", isVerbose)
 			output.Printf("Oh, by the way. Is marmite nice? %t\n", isMarmiteNice)
@@ -0,0 +1,162 @@
+package console
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/seeruk/go-console/parameters"
+	"github.com/seeruk/go-wordwrap"
+)
+
+// Example is an example of how to run a command, shown in help, and generated documentation.
+type Example struct {
+	// The arguments to run the application with, as they would be passed to Application.Run (i.e.
+	// the command path, followed by the command's own arguments and options).
+	Args []string
+	// An explanation of what the example does.
+	Description string
+}
+
+// CommandLine gets the command line used to run the example, starting with the given usage name.
+// Arguments are quoted if they need to be.
+func (e Example) CommandLine(usageName string) string {
+	words := []string{usageName}
+	for _, arg := range e.Args {
+		words = append(words, exampleQuote(arg))
+	}
+
+	return strings.Join(words, " ")
+}
+
+// ValidateExamples checks that the examples on each of an Application's commands (including the
+// root command, sub-commands, and hidden commands) are still valid. Each example must run the
+// command that it is on, and it's arguments and options must be parsed and mapped without error.
+// Environment variables aren't used. This is intended to be used in tests.
+//
+// Input is mapped to detached copies of the values that arguments and options reference (see
+// parameters.DetachValue), so validating examples doesn't change them, or the defaults shown in
+// help and generated documentation. Values that aren't defined in the parameters package are only
+// validated if they implement parameters.ValueValidator.
+func ValidateExamples(app *Application) error {
+	if app.rootCommand != nil {
+		if err := validateCommandExamples(app, app.rootCommand, nil); err != nil {
+			return err
+		}
+	}
+
+	var walk func(path []string, commands []*Command) error
+
+	walk = func(path []string, commands []*Command) error {
+		for _, cmd := range commands {
+			cmdPath := append(append([]string{}, path...), cmd.Name)
+
+			if err := validateCommandExamples(app, cmd, cmdPath); err != nil {
+				return err
+			}
+
+			if err := walk(cmdPath, cmd.Commands()); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}
+
+	return walk(nil, app.Commands())
+}
+
+// validateCommandExamples checks that each of the given command's examples are valid.
+func validateCommandExamples(app *Application, cmd *Command, path []string) error {
+	for _, example := range cmd.Examples {
+		resolved, resolvedPath := app.resolveCommand(example.Args, app.environ())
+		if resolved != cmd {
+			return fmt.Errorf(
+				"example '%s' does not run '%s'",
+				example.CommandLine(app.UsageName),
+				strings.Join(append([]string{app.UsageName}, path...), " "),
+			)
+		}
+
+		definition := detachDefinition(buildCommandDefinition(app, cmd))
+		input := ParseInput(definition, example.Args[len(resolvedPath):])
+
+		if err := MapInput(app.Name, definition, input, nil); err != nil {
+			return fmt.Errorf("example '%s' is invalid: %v", example.CommandLine(app.UsageName), err)
+		}
+	}
+
+	return nil
+}
+
+// detachDefinition copies the given definition, with arguments and options that reference detached
+// copies of their values, so that mapping input to it doesn't change the original values.
+func detachDefinition(definition *Definition) *Definition {
+	detached := NewDefinition()
+
+	for _, arg := range definition.Arguments() {
+		arg.Value = parameters.DetachValue(arg.Value)
+
+		detached.arguments[arg.Name] = arg
+		detached.argumentKeys = append(detached.argumentKeys, arg.Name)
+	}
+
+	for _, opt := range definition.Options() {
+		opt.Value = parameters.DetachValue(opt.Value)
+
+		for _, name := range opt.Names {
+			detached.options[name] = opt
+		}
+
+		detached.optionSet = append(detached.optionSet, opt)
+	}
+
+	return detached
+}
+
+// commandExamples gets the examples for the given command, or the root command's examples if the
+// command is nil (i.e. when describing the application itself).
+func commandExamples(app *Application, cmd *Command) []Example {
+	if cmd != nil {
+		return cmd.Examples
+	}
+
+	if app.rootCommand != nil {
+		return app.rootCommand.Examples
+	}
+
+	return nil
+}
+
+// describeExamples describes the given examples, each with it's description followed by an
+// indented command line, wrapped to the given width.
+func describeExamples(app *Application, examples []Example, width int) string {
+	var descs []string
+
+	wrapper := wordwrap.Wrapper(parameters.WrapWidth(width, 0), true)
+
+	for _, example := range examples {
+		var desc string
+
+		if example.Description != "" {
+			desc = wordwrap.Indent(wrapper(example.Description), "  ", true) + "\n"
+		}
+
+		desc += "    $ " + example.CommandLine(app.UsageName) + "\n"
+		descs = append(descs, desc)
+	}
+
+	return strings.Join(descs, "\n")
+}
+
+// unsafeShellChars matches characters that must be quoted in a POSIX-like shell.
+var unsafeShellChars = regexp.MustCompile(`[^A-Za-z0-9_@%+=:,./-]`)
+
+// exampleQuote quotes the given argument for use in a POSIX-like shell, if it needs to be.
+func exampleQuote(arg string) string {
+	if arg != "" && !unsafeShellChars.MatchString(arg) {
+		return arg
+	}
+
+	return shellQuote(arg)
+}
@@ -0,0 +1,240 @@
+package console_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/seeruk/go-console"
+	"github.com/seeruk/go-console/parameters"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExample(t *testing.T) {
+	t.Run("should describe the command line, quoting arguments if necessary", func(t *testing.T) {
+		example := console.Example{Args: []string{"greet", "--name=Jane Doe", "it's", ""}}
+
+		assert.Equal(t, `app greet '--name=Jane Doe' 'it'\''s' ''`, example.CommandLine("app"))
+	})
+
+	t.Run("should not quote arguments that don't need it", func(t *testing.T) {
+		example := console.Example{Args: []string{"db", "migrate", "--dsn=postgres://localhost/db", "-f"}}
+
+		assert.Equal(t, "app db migrate --dsn=postgres://localhost/db -f", example.CommandLine("app"))
+	})
+}
+
+func TestValidateExamples(t *testing.T) {
+	createApplication := func(examples ...console.Example) *console.Application {
+		var dsn string
+		var steps int
+
+		migrate := &console.Command{
+			Name:  "migrate",
+			Alias: "m",
+			Configure: func(definition *console.Definition) {
+				definition.AddOption(console.OptionDefinition{
+					Value: parameters.NewStringValue(&dsn),
+					Spec:  "--dsn=DSN",
+				})
+
+				definition.AddArgument(console.ArgumentDefinition{
+					Value: parameters.NewIntValue(&steps),
+					Spec:  "STEPS",
+				})
+			},
+			Examples: examples,
+		}
+
+		db := &console.Command{Name: "db"}
+		db.AddCommand(migrate)
+
+		application := console.NewApplication("App", "1.2.3")
+		application.UsageName = "app"
+		application.AddCommand(db)
+
+		return application
+	}
+
+	t.Run("should return nil if all examples are valid", func(t *testing.T) {
+		application := createApplication(
+			console.Example{Args: []string{"db", "migrate", "3"}},
+			console.Example{Args: []string{"db", "m", "--dsn=postgres://localhost", "3"}},
+		)
+
+		assert.NoError(t, console.ValidateExamples(application))
+	})
+
+	t.Run("should validate examples on the root command", func(t *testing.T) {
+		var name string
+
+		application := createApplication()
+		application.SetRootCommand(&console.Command{
+			Configure: func(definition *console.Definition) {
+				definition.AddArgument(console.ArgumentDefinition{
+					Value: parameters.NewStringValue(&name),
+					Spec:  "NAME",
+				})
+			},
+			Examples: []console.Example{{Args: []string{}}},
+		})
+
+		err := console.ValidateExamples(application)
+		assert.EqualError(t, err, "example 'app' is invalid: App: Argument 'NAME' is required")
+	})
+
+	t.Run("should error if an example runs a different command", func(t *testing.T) {
+		application := createApplication(console.Example{Args: []string{"db"}})
+
+		err := console.ValidateExamples(application)
+		assert.EqualError(t, err, "example 'app db' does not run 'app db migrate'")
+	})
+
+	t.Run("should error if an example's input can't be mapped", func(t *testing.T) {
+		application := createApplication(console.Example{Args: []string{"db", "migrate", "three"}})
+
+		err := console.ValidateExamples(application)
+		if assert.Error(t, err) {
+			assert.True(t, strings.HasPrefix(err.Error(), "example 'app db migrate three' is invalid: "))
+		}
+	})
+
+	t.Run("should error if an example is missing required arguments", func(t *testing.T) {
+		application := createApplication(console.Example{Args: []string{"db", "migrate"}})
+
+		assert.Error(t, console.ValidateExamples(application))
+	})
+
+	t.Run("should not change values, or the defaults shown in docs", func(t *testing.T) {
+		dsn := "localhost"
+
+		application := console.NewApplication("App", "1.2.3")
+		application.UsageName = "app"
+		application.AddCommand(&console.Command{
+			Name: "migrate",
+			Configure: func(definition *console.Definition) {
+				definition.AddOption(console.OptionDefinition{
+					Value: parameters.NewStringValue(&dsn),
+					Spec:  "--dsn=DSN",
+				})
+			},
+			Examples: []console.Example{
+				{Args: []string{"migrate", "--dsn=postgres://remote/db"}},
+			},
+		})
+
+		assert.NoError(t, console.ValidateExamples(application))
+		assert.Equal(t, "localhost", dsn)
+
+		pages, err := console.NewDocsGenerator(application).Generate()
+		assert.NoError(t, err)
+
+		for _, page := range pages {
+			if page.Name == "app_migrate" {
+				assert.Contains(t, page.Content, "| `--dsn=DSN` |  | `localhost` |  |\n")
+			}
+		}
+	})
+
+	t.Run("should not set values from outside the parameters package", func(t *testing.T) {
+		var tags []string
+
+		application := console.NewApplication("App", "1.2.3")
+		application.UsageName = "app"
+		application.AddCommand(&console.Command{
+			Name: "tag",
+			Configure: func(definition *console.Definition) {
+				definition.AddArgument(console.ArgumentDefinition{
+					Value: appendValue{ref: &tags},
+					Spec:  "TAG",
+				})
+			},
+			Examples: []console.Example{
+				{Args: []string{"tag", "latest"}},
+			},
+		})
+
+		assert.NoError(t, console.ValidateExamples(application))
+		assert.Empty(t, tags)
+	})
+}
+
+// appendValue is a Value that appends to a list each time it's set.
+type appendValue struct {
+	ref *[]string
+}
+
+func (v appendValue) Set(s string) error {
+	*v.ref = append(*v.ref, s)
+	return nil
+}
+
+func (v appendValue) String() string {
+	return strings.Join(*v.ref, ",")
+}
+
+func TestExamplesInHelp(t *testing.T) {
+	createApplication := func() (*console.Application, *console.Command) {
+		cmd := &console.Command{
+			Name: "migrate",
+			Examples: []console.Example{
+				{Args: []string{"migrate"}, Description: "Run all pending migrations."},
+				{Args: []string{"migrate", "3"}},
+			},
+		}
+
+		application := console.NewApplication("App", "1.2.3")
+		application.UsageName = "app"
+		application.AddCommand(cmd)
+
+		return application, cmd
+	}
+
+	t.Run("should show examples in command help", func(t *testing.T) {
+		application, cmd := createApplication()
+
+		result := console.DescribeCommand(application, cmd, []string{"migrate"})
+
+		assert.Contains(t, result, strings.Join([]string{
+			"EXAMPLES:",
+			"  Run all pending migrations.",
+			"    $ app migrate",
+			"",
+			"    $ app migrate 3",
+			"",
+		}, "\n"))
+	})
+
+	t.Run("should show examples in generated docs", func(t *testing.T) {
+		application, _ := createApplication()
+
+		pages, err := console.NewDocsGenerator(application).Generate()
+		assert.NoError(t, err)
+		assert.Contains(
+			t,
+			pages[1].Content,
+			"## Examples\n\nRun all pending migrations.\n\n```\n$ app migrate\n```\n\n```\n$ app migrate 3\n```\n",
+		)
+	})
+
+	t.Run("should show examples in generated man pages", func(t *testing.T) {
+		application, _ := createApplication()
+
+		pages := console.GenerateManPages(application)
+		assert.Contains(
+			t,
+			pages[1].Content,
+			".SH EXAMPLES\nRun all pending migrations.\n.PP\n.RS 4\n.nf\n$ app migrate\n.fi\n.RE\n"+
+				".PP\n.RS 4\n.nf\n$ app migrate 3\n.fi\n.RE\n",
+		)
+	})
+
+	t.Run("should include examples in the model", func(t *testing.T) {
+		application, _ := createApplication()
+
+		model := console.DescribeModel(application)
+		assert.Equal(t, []console.ExampleModel{
+			{Args: []string{"migrate"}, CommandLine: "app migrate", Description: "Run all pending migrations."},
+			{Args: []string{"migrate", "3"}, CommandLine: "app migrate 3"},
+		}, model.Commands[0].Examples)
+	})
+}
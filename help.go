@@ -0,0 +1,169 @@
+package console
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/seeruk/go-console/parameters"
+	"github.com/seeruk/go-wordwrap"
+)
+
+// HelpTopic is a free-form page of help, not tied to a specific command (e.g. information about
+// environment variables, or configuration files).
+type HelpTopic struct {
+	// The name of the topic, used to look it up (e.g. `app help NAME`).
+	Name string
+	// A short description of the topic, shown when listing topics.
+	Description string
+	// The content of the topic.
+	Help string
+}
+
+// DescribeHelpTopic describes a HelpTopic to show it's content.
+func DescribeHelpTopic(topic HelpTopic) string {
+	return DescribeHelpTopicWidth(topic, DefaultWidth)
+}
+
+// DescribeHelpTopicWidth describes a HelpTopic like DescribeHelpTopic does, wrapping it's
+// description so that lines are no wider than the given width.
+func DescribeHelpTopicWidth(topic HelpTopic, width int) string {
+	desc := fmt.Sprintf("%s:\n", strings.ToUpper(topic.Name))
+
+	if topic.Description != "" {
+		wrapper := wordwrap.Wrapper(parameters.WrapWidth(width, 0), true)
+
+		desc += wordwrap.Indent(wrapper(topic.Description), "  ", true) + "\n\n"
+	}
+
+	desc += wordwrap.Indent(topic.Help, "  ", true) + "\n"
+
+	return desc
+}
+
+// DescribeHelpTopics describes an array of HelpTopics to provide a listing of available topics.
+func DescribeHelpTopics(topics []HelpTopic) string {
+	return DescribeHelpTopicsWidth(topics, DefaultWidth)
+}
+
+// DescribeHelpTopicsWidth describes an array of HelpTopics like DescribeHelpTopics does, wrapping
+// descriptions so that lines are no wider than the given width.
+func DescribeHelpTopicsWidth(topics []HelpTopic, width int) string {
+	desc := "HELP TOPICS:\n"
+
+	// Create array and map for specific output ordering.
+	topicKeys := []string{}
+	topicMap := make(map[string]HelpTopic)
+
+	var column int
+	for _, topic := range topics {
+		topicKeys = append(topicKeys, topic.Name)
+		topicMap[topic.Name] = topic
+
+		if len(topic.Name) > (column - 2) {
+			column = len(topic.Name) + 2
+		}
+	}
+
+	sort.Strings(topicKeys)
+
+	for _, name := range topicKeys {
+		// Get space for the right-side of the topic name.
+		spacing := column - len(name)
+
+		// Wrap the description onto new lines if necessary.
+		wrapper := wordwrap.Wrapper(parameters.WrapWidth(width, column), true)
+		wrapped := wrapper(topicMap[name].Description)
+
+		// Indent and prefix to produce the result.
+		prefix := fmt.Sprintf("  %s%s", name, strings.Repeat(" ", spacing))
+
+		desc += wordwrap.Indent(wrapped, prefix, false) + "\n"
+	}
+
+	return desc
+}
+
+// newHelpCommand creates the built-in help command for the given application. It resolves the
+// given arguments to a command in the same way the application does, and shows that command's
+// help, as if the `--help` option had been used.
+func newHelpCommand(app *Application) *Command {
+	var name string
+	var help string
+
+	if len(app.helpTopics) > 0 {
+		help = fmt.Sprintf("Run `$ %s help topics` to list the available help topics.", app.UsageName)
+	}
+
+	return &Command{
+		Name:        "help",
+		Description: "Display help for a command, or a help topic.",
+		Help:        help,
+		Configure: func(definition *Definition) {
+			definition.AddArgument(ArgumentDefinition{
+				Value:             parameters.NewStringValue(&name),
+				Spec:              "[COMMAND]",
+				Desc:              "The command (or sub-command path), or help topic to show help for.",
+				Complete:          func(prefix string) []string { return completeHelpNames(app) },
+				CompleteDirective: parameters.CompleteNoFiles,
+			})
+		},
+		Execute: func(input *Input, output *Output) error {
+			var args []string
+			for _, arg := range input.Arguments {
+				args = append(args, arg.Value)
+			}
+
+			// Help is shown using the same exit code as when the help option is used.
+			output.SetExitCode(100)
+
+			if len(args) == 1 {
+				if args[0] == "topics" {
+					output.Println(DescribeHelpTopicsWidth(app.helpTopics, output.Width()))
+					return nil
+				}
+
+				for _, topic := range app.helpTopics {
+					if topic.Name == args[0] {
+						output.Println(DescribeHelpTopicWidth(topic, output.Width()))
+						return nil
+					}
+				}
+			}
+
+			cmd, path := app.resolveCommand(args, app.environ())
+			if len(path) != len(args) {
+				return fmt.Errorf("unknown command or help topic '%s'", strings.Join(args, " "))
+			}
+
+			if cmd != nil {
+				output.Println(DescribeCommand(app, cmd, path))
+			} else {
+				output.Println(DescribeApplication(app))
+			}
+
+			return nil
+		},
+	}
+}
+
+// completeHelpNames suggests the names of the commands, and help topics that help can be shown for.
+func completeHelpNames(app *Application) []string {
+	var suggestions []string
+
+	for _, cmd := range app.Commands() {
+		if !cmd.Hidden {
+			suggestions = append(suggestions, completionSuggestion(cmd.Name, cmd.Description))
+		}
+	}
+
+	if len(app.helpTopics) > 0 {
+		suggestions = append(suggestions, completionSuggestion("topics", "List the available help topics."))
+	}
+
+	for _, topic := range app.helpTopics {
+		suggestions = append(suggestions, completionSuggestion(topic.Name, topic.Description))
+	}
+
+	return suggestions
+}
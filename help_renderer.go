@@ -0,0 +1,297 @@
+package console
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"text/template"
+
+	"github.com/seeruk/go-console/parameters"
+	"github.com/seeruk/go-wordwrap"
+)
+
+// Names of the sections rendered by the default TemplateHelpRenderer, in their default order.
+const (
+	HelpSectionHeader    = "header"
+	HelpSectionUsage     = "usage"
+	HelpSectionArguments = "arguments"
+	HelpSectionOptions   = "options"
+	HelpSectionCommands  = "commands"
+	HelpSectionExamples  = "examples"
+	HelpSectionHelp      = "help"
+)
+
+// HelpRenderer renders help for an Application, or one of it's commands.
+type HelpRenderer interface {
+	// Render renders help using the given data.
+	Render(data HelpData) string
+}
+
+// HelpData is the data that help is rendered from. It is passed to each section's template in a
+// TemplateHelpRenderer, so it's methods can be used to describe parts of the help.
+type HelpData struct {
+	// The application that help is being rendered for.
+	App *Application
+	// The command that help is being rendered for, nil if it's for the application itself.
+	Command *Command
+	// The path taken to reach the command.
+	Path []string
+	// The width that help should be wrapped to.
+	Width int
+	// The arguments that may be given, in order.
+	Arguments []parameters.Argument
+	// The options that may be given, including global options.
+	Options []parameters.Option
+	// The commands that can be run after this one.
+	Commands []*Command
+}
+
+// CommandLine gets the command line used to run the command (e.g. "app db migrate").
+func (d HelpData) CommandLine() string {
+	return strings.Join(append([]string{d.App.UsageName}, d.Path...), " ")
+}
+
+// Usage describes how to run the application, or command, on a single line. Options that aren't
+// required are collapsed into `[OPTIONS]` if the line would be wider than the help (once indented).
+func (d HelpData) Usage() string {
+	return synopsisString(describeSynopsis(d.App, d.Command, d.Path, d.Arguments, d.Options, d.Commands, d.Width-2))
+}
+
+// Description gets the description of the command, empty for the application itself.
+func (d HelpData) Description() string {
+	if d.Command == nil {
+		return ""
+	}
+
+	return d.Command.Description
+}
+
+// Help gets the help message of the application, or command.
+func (d HelpData) Help() string {
+	if d.Command == nil {
+		return d.App.Help
+	}
+
+	return d.Command.Help
+}
+
+// ArgumentList describes the arguments, one per line, with their descriptions.
+func (d HelpData) ArgumentList() string {
+	if len(d.Arguments) == 0 {
+		return ""
+	}
+
+	return parameters.DescribeArgumentsFormat(d.Arguments, parameters.ArgumentsFormat{
+		Width:   d.Width,
+		NoTitle: true,
+	})
+}
+
+// OptionList describes the options, one per line, with their descriptions, in groups. The order of
+// the options, and the annotations added to them are configured on the application.
+func (d HelpData) OptionList() string {
+	if len(d.Options) == 0 {
+		return ""
+	}
+
+	return parameters.DescribeOptionsFormat(d.Options, parameters.OptionsFormat{
+		Width:       d.Width,
+		Annotations: d.App.OptionAnnotations,
+		Order:       d.App.OptionOrder,
+		NoTitle:     true,
+	})
+}
+
+// CommandList describes the commands that aren't hidden, one per line, with their descriptions.
+func (d HelpData) CommandList() string {
+	if !hasVisibleCommands(d.Commands) {
+		return ""
+	}
+
+	return describeCommandList(d.Commands, d.Width)
+}
+
+// ExampleList describes the command's examples, each with it's description, followed by the
+// command line used to run it.
+func (d HelpData) ExampleList() string {
+	if d.Command == nil {
+		return ""
+	}
+
+	return describeExamples(d.App, d.Command.Examples, d.Width)
+}
+
+// HelpHint describes how to get help for the commands that can be run after this one.
+func (d HelpData) HelpHint() string {
+	return describeHelpHint(d.CommandLine(), d.Width)
+}
+
+// Indent indents each line of the given text by 2 spaces.
+func (d HelpData) Indent(text string) string {
+	return wordwrap.Indent(text, "  ", true)
+}
+
+// Wrap wraps the given text to the width of the help, and indents it by 2 spaces.
+func (d HelpData) Wrap(text string) string {
+	wrapper := wordwrap.Wrapper(parameters.WrapWidth(d.Width, 0), true)
+
+	return d.Indent(wrapper(text))
+}
+
+// HelpSection is a section of help rendered by a TemplateHelpRenderer.
+type HelpSection struct {
+	// The name of the section, used to refer to it in the order sections are rendered in.
+	Name string
+	// The title of the section, shown as it's heading. If empty, the section has no heading.
+	Title string
+	// The text/template used to render the body of the section, given HelpData. If nothing but
+	// whitespace is rendered, the whole section is skipped.
+	Template string
+}
+
+// TemplateHelpRenderer is a HelpRenderer that renders help as a series of sections, each rendered
+// using a text/template. Sections can be replaced, reordered, or added to (e.g. to add a LEARN MORE
+// section), and the style of headings can be changed.
+type TemplateHelpRenderer struct {
+	// The names of the sections to render, in order. Sections not in this list are not rendered.
+	Order []string
+	// Function to format a section's title as a heading. If nil, the title is shown in upper-case,
+	// followed by a colon (e.g. "USAGE:").
+	Heading func(title string) string
+
+	sections  map[string]HelpSection
+	templates map[string]*template.Template
+}
+
+// NewTemplateHelpRenderer creates a new TemplateHelpRenderer, with the default sections.
+func NewTemplateHelpRenderer() *TemplateHelpRenderer {
+	renderer := &TemplateHelpRenderer{
+		sections:  make(map[string]HelpSection),
+		templates: make(map[string]*template.Template),
+	}
+
+	for _, section := range defaultHelpSections() {
+		renderer.SetSection(section)
+	}
+
+	return renderer
+}
+
+// defaultHelpSections gets the sections rendered by default, in their default order.
+func defaultHelpSections() []HelpSection {
+	return []HelpSection{
+		{
+			Name:     HelpSectionHeader,
+			Template: "{{if not .Command}}{{with .App.Logo}}{{.}}\n{{end}}{{.App.Name}} version {{.App.Version}}{{end}}",
+		},
+		{
+			Name:     HelpSectionUsage,
+			Title:    "Usage",
+			Template: "{{.Indent .Usage}}{{with .Description}}\n\n{{$.Wrap .}}{{end}}",
+		},
+		{
+			Name:     HelpSectionArguments,
+			Title:    "Arguments",
+			Template: "{{.ArgumentList}}",
+		},
+		{
+			Name:     HelpSectionOptions,
+			Title:    "Options",
+			Template: "{{.OptionList}}",
+		},
+		{
+			Name:     HelpSectionCommands,
+			Title:    "Commands",
+			Template: "{{with .CommandList}}{{.}}\n{{$.HelpHint}}{{end}}",
+		},
+		{
+			Name:     HelpSectionExamples,
+			Title:    "Examples",
+			Template: "{{.ExampleList}}",
+		},
+		{
+			Name:     HelpSectionHelp,
+			Title:    "Help",
+			Template: "{{with .Help}}{{$.Indent .}}{{end}}",
+		},
+	}
+}
+
+// SetSection adds a section, or replaces the section with the same name. New sections are added
+// to the end of the order. Panics if the section's template can't be parsed.
+func (r *TemplateHelpRenderer) SetSection(section HelpSection) {
+	tmpl, err := template.New(section.Name).Parse(section.Template)
+	if err != nil {
+		panic(fmt.Sprintf("console: invalid template for help section '%s': %v", section.Name, err))
+	}
+
+	if _, ok := r.sections[section.Name]; !ok {
+		r.Order = append(r.Order, section.Name)
+	}
+
+	r.sections[section.Name] = section
+	r.templates[section.Name] = tmpl
+}
+
+// Render renders each section in order, separated by blank lines. If a section's template can't be
+// executed, the default template for that section is used instead (or the section is skipped, if
+// it isn't one of the defaults), so that a broken template doesn't stop help from being shown.
+func (r *TemplateHelpRenderer) Render(data HelpData) string {
+	heading := r.Heading
+	if heading == nil {
+		heading = defaultHelpHeading
+	}
+
+	var sections []string
+
+	for _, name := range r.Order {
+		section, ok := r.sections[name]
+		if !ok {
+			continue
+		}
+
+		var body strings.Builder
+
+		if err := r.templates[name].Execute(&body, data); err != nil {
+			body.Reset()
+
+			if !renderDefaultHelpSection(&body, name, data) {
+				continue
+			}
+		}
+
+		if strings.TrimSpace(body.String()) == "" {
+			continue
+		}
+
+		var desc string
+		if section.Title != "" {
+			desc = heading(section.Title) + "\n"
+		}
+
+		sections = append(sections, desc+strings.TrimRight(body.String(), "\n")+"\n")
+	}
+
+	return strings.Join(sections, "\n")
+}
+
+// renderDefaultHelpSection renders the default template for the section with the given name. It
+// returns false if there's no default section with the name, or it can't be rendered.
+func renderDefaultHelpSection(w io.Writer, name string, data HelpData) bool {
+	for _, section := range defaultHelpSections() {
+		if section.Name != name {
+			continue
+		}
+
+		tmpl := template.Must(template.New(section.Name).Parse(section.Template))
+
+		return tmpl.Execute(w, data) == nil
+	}
+
+	return false
+}
+
+// defaultHelpHeading formats a section's title as a heading, in upper-case followed by a colon.
+func defaultHelpHeading(title string) string {
+	return strings.ToUpper(title) + ":"
+}
@@ -0,0 +1,185 @@
+package console_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/seeruk/go-console"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTemplateHelpRenderer(t *testing.T) {
+	createApplication := func() (*console.Application, *console.Command) {
+		cmd := &console.Command{
+			Name:        "migrate",
+			Description: "Run database migrations.",
+			Help:        "Migrations are run in order.",
+		}
+
+		application := console.NewApplication("App", "1.2.3")
+		application.UsageName = "app"
+		application.AddCommand(cmd)
+
+		return application, cmd
+	}
+
+	t.Run("should render sections in the default order", func(t *testing.T) {
+		application, cmd := createApplication()
+
+		result := console.DescribeCommand(application, cmd, []string{"migrate"})
+
+		assert.Equal(t, strings.Join([]string{
+			"USAGE:",
+			"  app migrate [-h]",
+			"",
+			"  Run database migrations.",
+			"",
+			"OPTIONS:",
+			"  -h, --help  Display contextual help?",
+			"",
+			"HELP:",
+			"  Migrations are run in order.",
+			"",
+		}, "\n"), result)
+	})
+
+	t.Run("should render sections in a custom order", func(t *testing.T) {
+		application, cmd := createApplication()
+
+		renderer := console.NewTemplateHelpRenderer()
+		renderer.Order = []string{console.HelpSectionHelp, console.HelpSectionUsage}
+
+		application.HelpRenderer = renderer
+
+		result := console.DescribeCommand(application, cmd, []string{"migrate"})
+
+		assert.True(t, strings.HasPrefix(result, "HELP:\n  Migrations are run in order.\n\nUSAGE:\n"))
+		assert.NotContains(t, result, "OPTIONS:")
+	})
+
+	t.Run("should render custom sections", func(t *testing.T) {
+		application, cmd := createApplication()
+
+		renderer := console.NewTemplateHelpRenderer()
+		renderer.SetSection(console.HelpSection{
+			Name:     "learn-more",
+			Title:    "Learn more",
+			Template: "{{if .Command}}  Read the docs for '{{.CommandLine}}' online.{{end}}",
+		})
+
+		application.HelpRenderer = renderer
+
+		result := console.DescribeCommand(application, cmd, []string{"migrate"})
+		assert.True(t, strings.HasSuffix(result, "\nLEARN MORE:\n  Read the docs for 'app migrate' online.\n"))
+
+		result = console.DescribeApplication(application)
+		assert.NotContains(t, result, "LEARN MORE:")
+	})
+
+	t.Run("should replace existing sections", func(t *testing.T) {
+		application, cmd := createApplication()
+
+		renderer := console.NewTemplateHelpRenderer()
+		renderer.SetSection(console.HelpSection{
+			Name:     console.HelpSectionUsage,
+			Title:    "Synopsis",
+			Template: "  {{.Usage}}",
+		})
+
+		application.HelpRenderer = renderer
+
+		result := console.DescribeCommand(application, cmd, []string{"migrate"})
+
+		assert.True(t, strings.HasPrefix(result, "SYNOPSIS:\n  app migrate [-h]\n\nOPTIONS:\n"))
+	})
+
+	t.Run("should use a custom heading style", func(t *testing.T) {
+		application, cmd := createApplication()
+
+		renderer := console.NewTemplateHelpRenderer()
+		renderer.Heading = func(title string) string {
+			return "== " + title + " =="
+		}
+
+		application.HelpRenderer = renderer
+
+		result := console.DescribeCommand(application, cmd, []string{"migrate"})
+
+		assert.Contains(t, result, "== Usage ==\n")
+		assert.Contains(t, result, "== Options ==\n")
+		assert.NotContains(t, result, "USAGE:")
+	})
+
+	t.Run("should panic if a section's template is invalid", func(t *testing.T) {
+		renderer := console.NewTemplateHelpRenderer()
+
+		assert.Panics(t, func() {
+			renderer.SetSection(console.HelpSection{Name: "broken", Template: "{{.Usage"})
+		})
+	})
+
+	t.Run("should fall back to the default template if a section's template fails", func(t *testing.T) {
+		application, cmd := createApplication()
+
+		renderer := console.NewTemplateHelpRenderer()
+		renderer.SetSection(console.HelpSection{
+			Name:     console.HelpSectionUsage,
+			Title:    "Synopsis",
+			Template: "  {{.Usgae}}",
+		})
+
+		application.HelpRenderer = renderer
+
+		var result string
+		assert.NotPanics(t, func() {
+			result = console.DescribeCommand(application, cmd, []string{"migrate"})
+		})
+
+		assert.True(t, strings.HasPrefix(result, "SYNOPSIS:\n  app migrate [-h]\n\n  Run database migrations.\n\nOPTIONS:\n"))
+	})
+
+	t.Run("should skip custom sections if their template fails", func(t *testing.T) {
+		application, cmd := createApplication()
+
+		renderer := console.NewTemplateHelpRenderer()
+		renderer.SetSection(console.HelpSection{
+			Name:     "learn-more",
+			Title:    "Learn more",
+			Template: "  Read the docs for '{{.CommandLnie}}' online.",
+		})
+
+		application.HelpRenderer = renderer
+
+		result := console.DescribeCommand(application, cmd, []string{"migrate"})
+
+		assert.NotContains(t, result, "LEARN MORE:")
+		assert.True(t, strings.HasSuffix(result, "HELP:\n  Migrations are run in order.\n"))
+	})
+}
+
+func TestApplication_HelpRenderer(t *testing.T) {
+	t.Run("should be used to render help", func(t *testing.T) {
+		var data console.HelpData
+
+		application := console.NewApplication("App", "1.2.3")
+		application.UsageName = "app"
+		application.HelpRenderer = helpRendererFunc(func(d console.HelpData) string {
+			data = d
+			return "custom help"
+		})
+
+		application.AddCommand(&console.Command{Name: "migrate"})
+
+		assert.Equal(t, "custom help", console.DescribeApplication(application))
+		assert.Nil(t, data.Command)
+		assert.Len(t, data.Commands, 1)
+		assert.Equal(t, "app", data.CommandLine())
+	})
+}
+
+// helpRendererFunc is a console.HelpRenderer implemented by a function.
+type helpRendererFunc func(data console.HelpData) string
+
+func (f helpRendererFunc) Render(data console.HelpData) string {
+	return f(data)
+}
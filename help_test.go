@@ -0,0 +1,130 @@
+package console_test
+
+import (
+	"bytes"
+	"io/ioutil"
+	"strings"
+	"testing"
+
+	"github.com/seeruk/go-console"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDescribeHelpTopic(t *testing.T) {
+	t.Run("should include the topic name and help", func(t *testing.T) {
+		result := console.DescribeHelpTopic(console.HelpTopic{
+			Name: "environment",
+			Help: "Some help about the environment.",
+		})
+
+		assert.True(t, strings.Contains(result, "ENVIRONMENT:"), "Expected topic title.")
+		assert.True(t, strings.Contains(result, "Some help about the environment."), "Expected topic help.")
+	})
+}
+
+func TestDescribeHelpTopics(t *testing.T) {
+	t.Run("should include a title", func(t *testing.T) {
+		result := console.DescribeHelpTopics([]console.HelpTopic{})
+
+		assert.True(t, strings.Contains(result, "HELP TOPICS:"), "Expected a title.")
+	})
+
+	t.Run("should sort topics into alphabetical order", func(t *testing.T) {
+		result := console.DescribeHelpTopics([]console.HelpTopic{
+			{Name: "foo", Description: "Foo topic."},
+			{Name: "bar", Description: "Bar topic."},
+		})
+
+		fooIdx := strings.Index(result, "foo")
+		barIdx := strings.Index(result, "bar")
+
+		assert.True(t, fooIdx > barIdx, "Expected foo to come after bar.")
+		assert.True(t, strings.Contains(result, "Foo topic."), "Expected topic description.")
+	})
+}
+
+func TestHelpCommand(t *testing.T) {
+	createApplication := func(writer *bytes.Buffer) *console.Application {
+		greetCommand := &console.Command{
+			Name:        "greet",
+			Description: "Greet someone.",
+			Execute: func(input *console.Input, output *console.Output) error {
+				return nil
+			},
+		}
+
+		greetCommand.AddCommand(&console.Command{
+			Name:        "sub",
+			Description: "A greet sub-command.",
+			Execute: func(input *console.Input, output *console.Output) error {
+				return nil
+			},
+		})
+
+		application := console.NewApplication("seeruk/go-console", "1.2.3+testing")
+		application.Writer = writer
+		application.ErrWriter = ioutil.Discard
+		application.EnableHelpCommand = true
+		application.AddCommand(greetCommand)
+		application.AddHelpTopic(console.HelpTopic{
+			Name:        "environment",
+			Description: "Environment variables.",
+			Help:        "All about environment variables.",
+		})
+
+		return application
+	}
+
+	t.Run("should show the same help as the help option for a command", func(t *testing.T) {
+		expected := bytes.Buffer{}
+		createApplication(&expected).Run([]string{"greet", "sub", "--help"}, []string{})
+
+		writer := bytes.Buffer{}
+		code := createApplication(&writer).Run([]string{"help", "greet", "sub"}, []string{})
+
+		assert.Equal(t, 100, code)
+		assert.Equal(t, expected.String(), writer.String())
+	})
+
+	t.Run("should show application help if no command is given", func(t *testing.T) {
+		writer := bytes.Buffer{}
+		code := createApplication(&writer).Run([]string{"help"}, []string{})
+
+		assert.Equal(t, 100, code)
+		assert.True(t, strings.Contains(writer.String(), "COMMANDS:"), "Expected application help.")
+	})
+
+	t.Run("should show help topics", func(t *testing.T) {
+		writer := bytes.Buffer{}
+		code := createApplication(&writer).Run([]string{"help", "environment"}, []string{})
+
+		assert.Equal(t, 100, code)
+		assert.True(t, strings.Contains(writer.String(), "All about environment variables."), "Expected topic.")
+	})
+
+	t.Run("should list help topics", func(t *testing.T) {
+		writer := bytes.Buffer{}
+		code := createApplication(&writer).Run([]string{"help", "topics"}, []string{})
+
+		assert.Equal(t, 100, code)
+		assert.True(t, strings.Contains(writer.String(), "HELP TOPICS:"), "Expected topics title.")
+		assert.True(t, strings.Contains(writer.String(), "environment"), "Expected topic name.")
+	})
+
+	t.Run("should fail if the command or topic is unknown", func(t *testing.T) {
+		errWriter := bytes.Buffer{}
+		application := createApplication(&bytes.Buffer{})
+		application.ErrWriter = &errWriter
+
+		code := application.Run([]string{"help", "greet", "nope"}, []string{})
+
+		assert.Equal(t, 1, code)
+		assert.True(t, strings.Contains(errWriter.String(), "unknown command or help topic 'greet nope'"), "Expected error.")
+	})
+
+	t.Run("should be listed in application help", func(t *testing.T) {
+		result := console.DescribeApplication(createApplication(&bytes.Buffer{}))
+
+		assert.True(t, strings.Contains(result, "Display help for a command"), "Expected help command.")
+	})
+}
@@ -1,11 +1,19 @@
 package console
 
+import (
+	"io"
+	"io/ioutil"
+	"os"
+)
+
 // Input represents the raw application input, in a slightly more organised way, and provides
 // helpers for retrieving that information. This allows commands to get application-wide input,
 // instead of only the command-specific input.
 type Input struct {
 	Arguments []InputArgument
 	Options   []InputOption
+	// Reader to read input from (i.e. stdin). If nil, os.Stdin is used.
+	Reader io.Reader
 }
 
 // InputArgument represents the raw data parsed as arguments, really this is just the value.
@@ -45,3 +53,33 @@ func (i *Input) HasOption(names []string) bool {
 
 	return false
 }
+
+// GetReader gets the reader that input should be read from, defaulting to os.Stdin.
+func (i *Input) GetReader() io.Reader {
+	if i.Reader == nil {
+		return os.Stdin
+	}
+
+	return i.Reader
+}
+
+// IsTerminal checks to see if the input reader is a terminal (i.e. a human could be typing input).
+func (i *Input) IsTerminal() bool {
+	return isTerminal(i.GetReader())
+}
+
+// IsPiped checks to see if the input reader is not a terminal (i.e. input is being piped, or
+// redirected from a file).
+func (i *Input) IsPiped() bool {
+	return !i.IsTerminal()
+}
+
+// Open opens the file at the given path for reading. By convention, a path of "-" means the input
+// reader is used instead, allowing file-type options and arguments to accept piped input.
+func (i *Input) Open(path string) (io.ReadCloser, error) {
+	if path == "-" {
+		return ioutil.NopCloser(i.GetReader()), nil
+	}
+
+	return os.Open(path)
+}
@@ -21,6 +21,10 @@ func MapInput(name string, definition *Definition, input *Input, env []string) e
 		return err
 	}
 
+	if err := checkRequiredOptions(name, definition.Options(), input, env); err != nil {
+		return err
+	}
+
 	return nil
 }
 
@@ -104,6 +108,43 @@ func mapEnv(name string, opts []parameters.Option, env []string) error {
 	return nil
 }
 
+// checkRequiredOptions checks that all required options have been given, either in the input, or
+// in the environment.
+func checkRequiredOptions(name string, opts []parameters.Option, input *Input, env []string) error {
+	for _, opt := range opts {
+		if opt.Required && !isOptionProvided(opt, input, env) {
+			return fmt.Errorf("%s: Option '%s' is required", name, optionDisplayName(opt))
+		}
+	}
+
+	return nil
+}
+
+// isOptionProvided checks to see if the given option has been given in the input, or environment.
+func isOptionProvided(opt parameters.Option, input *Input, env []string) bool {
+	if findOptionInInput(opt, input) != nil {
+		return true
+	}
+
+	return opt.EnvVar != "" && lookupEnv(env, opt.EnvVar) != ""
+}
+
+// optionDisplayName gets the name of the given option as it would be entered, preferring the first
+// long name (e.g. "--name" over "-n").
+func optionDisplayName(opt parameters.Option) string {
+	for _, name := range opt.Names {
+		if len(name) > 1 {
+			return "--" + name
+		}
+	}
+
+	if len(opt.Names) > 0 {
+		return "-" + opt.Names[0]
+	}
+
+	return ""
+}
+
 // setOptionValue sets the value of an option, and handles potential error cases.
 func setOptionValue(name string, opt parameters.Option, optName string, value string) error {
 	if opt.ValueMode == parameters.OptionValueRequired && value == "" {
@@ -120,6 +161,16 @@ func setOptionValue(name string, opt parameters.Option, optName string, value st
 			return fmt.Errorf("%s: Invalid default value '%s' for option '%s'. Error: %s", name, value, optName, err)
 		}
 	} else if !isEmptyOptional {
+		if len(opt.Choices) > 0 && !containsString(opt.Choices, value) {
+			return fmt.Errorf(
+				"%s: Invalid value '%s' for option '%s'. Expected one of: %s",
+				name,
+				value,
+				optName,
+				strings.Join(opt.Choices, ", "),
+			)
+		}
+
 		err := opt.Value.Set(value)
 		if err != nil {
 			return fmt.Errorf("%s: Invalid value '%s' for option '%s'. Error: %s", name, value, optName, err)
@@ -129,6 +180,17 @@ func setOptionValue(name string, opt parameters.Option, optName string, value st
 	return nil
 }
 
+// containsString checks to see if the given value is in the given slice of strings.
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+
+	return false
+}
+
 // findOptionInInput finds a given option in the given parsed raw input.
 func findOptionInInput(opt parameters.Option, input *Input) *InputOption {
 	inputOptions := make(map[string]InputOption)
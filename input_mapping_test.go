@@ -342,4 +342,77 @@ func TestMapInput(t *testing.T) {
 
 		assert.Error(t, err)
 	})
+
+	t.Run("should error when required options are missing", func(t *testing.T) {
+		var s1 string
+
+		definition := console.NewDefinition()
+		definition.AddOption(console.OptionDefinition{
+			Value:    parameters.NewStringValue(&s1),
+			Spec:     "-s, --s1=S1",
+			Required: true,
+		})
+
+		input := createInput(definition, []string{})
+
+		err := console.MapInput("test", definition, input, []string{})
+		assert.EqualError(t, err, "test: Option '--s1' is required")
+	})
+
+	t.Run("should not error when required options are set by env vars", func(t *testing.T) {
+		var s1 string
+
+		definition := console.NewDefinition()
+		definition.AddOption(console.OptionDefinition{
+			Value:    parameters.NewStringValue(&s1),
+			Spec:     "--s1=S1",
+			EnvVar:   "S1",
+			Required: true,
+		})
+
+		input := createInput(definition, []string{})
+
+		err := console.MapInput("test", definition, input, []string{"S1=hello"})
+		assert.NoError(t, err)
+		assert.Equal(t, "hello", s1)
+	})
+
+	t.Run("should map options with values that are one of their choices", func(t *testing.T) {
+		var s1 string
+
+		definition := console.NewDefinition()
+		definition.AddOption(console.OptionDefinition{
+			Value:   parameters.NewStringValue(&s1),
+			Spec:    "--s1=S1",
+			Choices: []string{"foo", "bar"},
+		})
+
+		input := createInput(definition, []string{"--s1=bar"})
+
+		err := console.MapInput("test", definition, input, []string{})
+		assert.NoError(t, err)
+		assert.Equal(t, "bar", s1)
+	})
+
+	t.Run("should error when option values aren't one of their choices", func(t *testing.T) {
+		var s1 string
+
+		definition := console.NewDefinition()
+		definition.AddOption(console.OptionDefinition{
+			Value:   parameters.NewStringValue(&s1),
+			Spec:    "--s1=S1",
+			EnvVar:  "S1",
+			Choices: []string{"foo", "bar"},
+		})
+
+		input := createInput(definition, []string{"--s1=baz"})
+
+		err := console.MapInput("test", definition, input, []string{})
+		assert.EqualError(t, err, "test: Invalid value 'baz' for option 's1'. Expected one of: foo, bar")
+
+		input = createInput(definition, []string{})
+
+		err = console.MapInput("test", definition, input, []string{"S1=baz"})
+		assert.EqualError(t, err, "test: Invalid value 'baz' for option 'S1'. Expected one of: foo, bar")
+	})
 }
@@ -0,0 +1,87 @@
+package console
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/seeruk/go-console/parameters"
+)
+
+// PromptMissingInput asks for any required arguments and options that are missing from the given
+// input (and environment), adding the answers to the input so that they can be mapped as usual.
+// Each parameter's description is used as the question, and secret parameters are asked for
+// without showing what is typed. Options with choices are asked for by choosing from a list. Answers
+// are validated without setting the parameter's value (see parameters.ValidateValue), and the
+// question is asked again if that fails, so values are only set once the input is mapped. If the
+// prompter is not interactive then nothing is asked, and mapping will report the missing input
+// instead.
+func PromptMissingInput(prompter *Prompter, definition *Definition, input *Input, env []string) error {
+	if !prompter.Interactive {
+		return nil
+	}
+
+	// Arguments are positional, so we can only ask for required arguments up until the first
+	// missing optional argument.
+	arguments := definition.Arguments()
+
+	for i := len(input.Arguments); i < len(arguments); i++ {
+		arg := arguments[i]
+		if !arg.Required {
+			break
+		}
+
+		answer, err := promptForValue(prompter, arg.Name, arg.Description, arg.Secret, nil, arg.Value)
+		if err != nil {
+			return err
+		}
+
+		input.Arguments = append(input.Arguments, InputArgument{Value: answer})
+	}
+
+	for _, opt := range definition.Options() {
+		// Flags can't be asked for, as they have no value.
+		if !opt.Required || opt.ValueMode == parameters.OptionValueNone {
+			continue
+		}
+
+		if isOptionProvided(opt, input, env) {
+			continue
+		}
+
+		answer, err := promptForValue(prompter, optionDisplayName(opt), opt.Description, opt.Secret, opt.Choices, opt.Value)
+		if err != nil {
+			return err
+		}
+
+		input.Options = append(input.Options, InputOption{Name: opt.Names[0], Value: answer})
+	}
+
+	return nil
+}
+
+// promptForValue asks for a value for a parameter, validating the answer using the given Value. If
+// there are choices, then one of them must be chosen.
+func promptForValue(prompter *Prompter, name string, description string, secret bool, choices []string, value parameters.Value) (string, error) {
+	message := description
+	if message == "" {
+		message = name
+	}
+
+	if len(choices) > 0 && !secret {
+		return prompter.Select(SelectPrompt{Message: message, Options: choices})
+	}
+
+	validate := func(answer string) error {
+		if len(choices) > 0 && !containsString(choices, answer) {
+			return fmt.Errorf("invalid value '%s', expected one of: %s", answer, strings.Join(choices, ", "))
+		}
+
+		return parameters.ValidateValue(value, answer)
+	}
+
+	if secret {
+		return prompter.Password(PasswordPrompt{Message: message, Validate: validate})
+	}
+
+	return prompter.Text(TextPrompt{Message: message, Validate: validate})
+}
@@ -0,0 +1,181 @@
+package console_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/seeruk/go-console"
+	"github.com/seeruk/go-console/parameters"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPromptMissingInput(t *testing.T) {
+	createDefinition := func(number *int, name *string, token *string) *console.Definition {
+		definition := console.NewDefinition()
+		definition.AddArgument(console.ArgumentDefinition{
+			Value: parameters.NewIntValue(number),
+			Spec:  "NUMBER",
+			Desc:  "Your favourite number",
+		})
+
+		definition.AddOption(console.OptionDefinition{
+			Value:    parameters.NewStringValue(name),
+			Spec:     "-n, --name=NAME",
+			EnvVar:   "NAME",
+			Required: true,
+		})
+
+		definition.AddOption(console.OptionDefinition{
+			Value:    parameters.NewStringValue(token),
+			Spec:     "--token=TOKEN",
+			Desc:     "API token",
+			Required: true,
+			Secret:   true,
+		})
+
+		return definition
+	}
+
+	createPrompter := func(input *console.Input, buffer *bytes.Buffer) *console.Prompter {
+		prompter := console.NewPrompter(input, console.NewOutput(buffer))
+		prompter.Interactive = true
+
+		return prompter
+	}
+
+	t.Run("should prompt for missing required arguments and options", func(t *testing.T) {
+		var number int
+		var name, token string
+
+		buffer := bytes.Buffer{}
+		definition := createDefinition(&number, &name, &token)
+
+		input := console.ParseInput(definition, []string{})
+		input.Reader = strings.NewReader("42\nAlice\nsecret\n")
+
+		err := console.PromptMissingInput(createPrompter(input, &buffer), definition, input, []string{})
+		assert.NoError(t, err)
+
+		err = console.MapInput("test", definition, input, []string{})
+		assert.NoError(t, err)
+
+		assert.Equal(t, 42, number)
+		assert.Equal(t, "Alice", name)
+		assert.Equal(t, "secret", token)
+		assert.Contains(t, buffer.String(), "Your favourite number")
+		assert.Contains(t, buffer.String(), "--name")
+		assert.Contains(t, buffer.String(), "API token")
+	})
+
+	t.Run("should not prompt for input that was given", func(t *testing.T) {
+		var number int
+		var name, token string
+
+		buffer := bytes.Buffer{}
+		definition := createDefinition(&number, &name, &token)
+
+		input := console.ParseInput(definition, []string{"42", "--token=secret"})
+		input.Reader = strings.NewReader("")
+
+		err := console.PromptMissingInput(createPrompter(input, &buffer), definition, input, []string{"NAME=Alice"})
+
+		assert.NoError(t, err)
+		assert.Equal(t, "", buffer.String())
+	})
+
+	t.Run("should prompt again if the answer is invalid", func(t *testing.T) {
+		var number int
+		var name, token string
+
+		buffer := bytes.Buffer{}
+		definition := createDefinition(&number, &name, &token)
+
+		input := console.ParseInput(definition, []string{"--name=Alice", "--token=secret"})
+		input.Reader = strings.NewReader("forty-two\n42\n")
+
+		err := console.PromptMissingInput(createPrompter(input, &buffer), definition, input, []string{})
+		assert.NoError(t, err)
+
+		assert.Equal(t, 2, strings.Count(buffer.String(), "Your favourite number"))
+		assert.Equal(t, "42", input.Arguments[0].Value)
+		assert.Equal(t, 0, number, "Expected the value to only be set when mapped.")
+	})
+
+	t.Run("should ask for options with choices to be chosen from a list", func(t *testing.T) {
+		var level string
+
+		buffer := bytes.Buffer{}
+
+		definition := console.NewDefinition()
+		definition.AddOption(console.OptionDefinition{
+			Value:    parameters.NewStringValue(&level),
+			Spec:     "--level=LEVEL",
+			Desc:     "Log level",
+			Choices:  []string{"debug", "info"},
+			Required: true,
+		})
+
+		input := console.ParseInput(definition, []string{})
+		input.Reader = strings.NewReader("trace\ninfo\n")
+
+		err := console.PromptMissingInput(createPrompter(input, &buffer), definition, input, []string{})
+		assert.NoError(t, err)
+
+		err = console.MapInput("test", definition, input, []string{})
+		assert.NoError(t, err)
+
+		assert.Equal(t, 2, strings.Count(buffer.String(), "Log level"))
+		assert.Equal(t, "info", level)
+	})
+
+	t.Run("should not prompt if input is not interactive", func(t *testing.T) {
+		var number int
+		var name, token string
+
+		definition := createDefinition(&number, &name, &token)
+
+		input := console.ParseInput(definition, []string{})
+		input.Reader = strings.NewReader("42\n")
+
+		prompter := console.NewPrompter(input, console.NewOutput(&bytes.Buffer{}))
+
+		err := console.PromptMissingInput(prompter, definition, input, []string{})
+
+		assert.NoError(t, err)
+		assert.Len(t, input.Arguments, 0)
+	})
+}
+
+func TestApplicationPromptMissing(t *testing.T) {
+	t.Run("should prompt for missing input when enabled", func(t *testing.T) {
+		var name string
+
+		writer := bytes.Buffer{}
+
+		application := console.NewApplication("seeruk/go-console", "1.2.3+testing")
+		application.Reader = strings.NewReader("Alice\n")
+		application.Writer = &writer
+		application.ErrWriter = &bytes.Buffer{}
+		application.ForceInteractive = true
+		application.AddCommand(&console.Command{
+			Name:          "greet",
+			PromptMissing: true,
+			Configure: func(definition *console.Definition) {
+				definition.AddArgument(console.ArgumentDefinition{
+					Value: parameters.NewStringValue(&name),
+					Spec:  "NAME",
+				})
+			},
+			Execute: func(input *console.Input, output *console.Output) error {
+				output.Printf("Hello, %s!", name)
+				return nil
+			},
+		})
+
+		code := application.Run([]string{"greet"}, []string{})
+
+		assert.Equal(t, 0, code)
+		assert.Equal(t, "Hello, Alice!", writer.String())
+	})
+}
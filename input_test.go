@@ -1,6 +1,10 @@
 package console_test
 
 import (
+	"io/ioutil"
+	"os"
+	"runtime"
+	"strings"
 	"testing"
 
 	"github.com/seeruk/go-console"
@@ -30,6 +34,80 @@ func TestInput(t *testing.T) {
 	})
 }
 
+func TestInputReader(t *testing.T) {
+	t.Run("GetReader", func(t *testing.T) {
+		t.Run("should return the reader if one is set", func(t *testing.T) {
+			reader := strings.NewReader("hello")
+			input := console.Input{Reader: reader}
+
+			assert.Equal(t, reader, input.GetReader())
+		})
+
+		t.Run("should return stdin if no reader is set", func(t *testing.T) {
+			input := console.Input{}
+
+			assert.Equal(t, os.Stdin, input.GetReader())
+		})
+	})
+
+	t.Run("IsTerminal", func(t *testing.T) {
+		t.Run("should return false if the reader is not a terminal", func(t *testing.T) {
+			input := console.Input{Reader: strings.NewReader("hello")}
+
+			assert.False(t, input.IsTerminal(), "Expected reader not to be a terminal")
+			assert.True(t, input.IsPiped(), "Expected reader to be piped")
+		})
+
+		t.Run("should return false if the reader is a device that is not a terminal", func(t *testing.T) {
+			if runtime.GOOS != "linux" {
+				t.Skip("character devices can only be told apart from terminals on linux")
+			}
+
+			devNull, err := os.Open(os.DevNull)
+			assert.NoError(t, err)
+			defer devNull.Close()
+
+			input := console.Input{Reader: devNull}
+
+			assert.False(t, input.IsTerminal(), "Expected reader not to be a terminal")
+		})
+	})
+
+	t.Run("Open", func(t *testing.T) {
+		t.Run("should open the input reader if the path is '-'", func(t *testing.T) {
+			input := console.Input{Reader: strings.NewReader("hello")}
+
+			reader, err := input.Open("-")
+			assert.NoError(t, err)
+
+			bs, err := ioutil.ReadAll(reader)
+			assert.NoError(t, err)
+			assert.Equal(t, "hello", string(bs))
+		})
+
+		t.Run("should open files", func(t *testing.T) {
+			file, err := ioutil.TempFile("", "go-console-input")
+			assert.NoError(t, err)
+
+			defer os.Remove(file.Name())
+
+			file.WriteString("hello from a file")
+			file.Close()
+
+			input := console.Input{Reader: strings.NewReader("hello")}
+
+			reader, err := input.Open(file.Name())
+			assert.NoError(t, err)
+
+			defer reader.Close()
+
+			bs, err := ioutil.ReadAll(reader)
+			assert.NoError(t, err)
+			assert.Equal(t, "hello from a file", string(bs))
+		})
+	})
+}
+
 func createTestInput(names []string) console.Input {
 	opts := []console.InputOption{}
 
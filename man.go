@@ -0,0 +1,339 @@
+package console
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/seeruk/go-console/parameters"
+)
+
+// ManSection is the section of the manual that generated man pages are in (user commands).
+const ManSection = "1"
+
+// ManPage is a generated man page, in roff format.
+type ManPage struct {
+	// The name of the page (e.g. "app-db-migrate"), used to refer to it from other pages.
+	Name string
+	// The content of the page.
+	Content string
+}
+
+// FileName gets the name of the file the page should be installed as (e.g. "app-db-migrate.1").
+func (p ManPage) FileName() string {
+	return p.Name + "." + ManSection
+}
+
+// GenerateManPages generates man pages for an Application, one for the application itself, and
+// one for each of it's commands (including sub-commands). Hidden commands are skipped. Pages for
+// commands are named after the application's usage name, followed by the command path, separated
+// by hyphens (e.g. "app-db-migrate").
+func GenerateManPages(app *Application) []ManPage {
+	root := describeManPage(app, nil, nil, app.Commands())
+	pages := []ManPage{root}
+
+	var walk func(path []string, commands []*Command)
+
+	walk = func(path []string, commands []*Command) {
+		for _, cmd := range commands {
+			if cmd.Hidden {
+				continue
+			}
+
+			cmdPath := append(append([]string{}, path...), cmd.Name)
+
+			pages = append(pages, describeManPage(app, cmd, cmdPath, cmd.Commands()))
+			walk(cmdPath, cmd.Commands())
+		}
+	}
+
+	walk(nil, app.Commands())
+
+	return pages
+}
+
+// WriteManPages generates man pages for an Application (see GenerateManPages), and writes them to
+// files in the given directory, creating it if it doesn't exist.
+func WriteManPages(app *Application, dir string) error {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return err
+	}
+
+	for _, page := range GenerateManPages(app) {
+		err := ioutil.WriteFile(filepath.Join(dir, page.FileName()), []byte(page.Content), 0644)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// describeManPage describes the given command (or the application itself, if the command is nil)
+// as a man page. The commands given are those that can be run after this one.
+func describeManPage(app *Application, cmd *Command, path []string, commands []*Command) ManPage {
+	var page strings.Builder
+
+	name := manPageName(app, path)
+	definition := buildCommandDefinition(app, cmd)
+	arguments := definition.Arguments()
+	groups := parameters.GroupOptions(definition.Options(), app.OptionOrder)
+
+	summary := app.Name
+	description := app.Help
+
+	if cmd != nil {
+		summary = firstLine(cmd.Description)
+		description = strings.TrimSpace(cmd.Description + "\n\n" + cmd.Help)
+	} else if app.rootCommand != nil {
+		description = strings.TrimSpace(app.rootCommand.Description + "\n\n" + app.Help)
+	}
+
+	fmt.Fprintf(
+		&page,
+		".TH %s %s \"\" %s \"General Commands Manual\"\n",
+		manQuote(strings.ToUpper(name)),
+		ManSection,
+		manQuote(strings.TrimSpace(app.Name+" "+app.Version)),
+	)
+
+	page.WriteString(".SH NAME\n")
+	page.WriteString(manEscape(name))
+
+	if summary != "" {
+		page.WriteString(" \\- " + manEscape(summary))
+	}
+
+	page.WriteString("\n")
+
+	page.WriteString(".SH SYNOPSIS\n")
+	page.WriteString(describeManSynopsis(app, cmd, path, arguments, definition.Options(), commands))
+
+	if description != "" {
+		page.WriteString(".SH DESCRIPTION\n")
+		page.WriteString(manParagraphs(description))
+	}
+
+	if len(arguments) > 0 {
+		page.WriteString(".SH ARGUMENTS\n")
+
+		for _, arg := range arguments {
+			page.WriteString(".TP\n")
+			fmt.Fprintf(&page, "\\fI%s\\fR\n", manEscape(arg.Name))
+
+			if arg.Description != "" {
+				page.WriteString(manEscapeLines(arg.Description) + "\n")
+			}
+		}
+	}
+
+	if len(groups) > 0 {
+		page.WriteString(".SH OPTIONS\n")
+
+		for _, group := range groups {
+			if group.Name != "" {
+				fmt.Fprintf(&page, ".SS %s\n", manEscape(group.Name))
+			}
+
+			for _, opt := range group.Options {
+				page.WriteString(".TP\n")
+				page.WriteString(describeManOption(opt) + "\n")
+
+				if opt.Description != "" {
+					page.WriteString(manEscapeLines(opt.Description) + "\n")
+				}
+			}
+		}
+	}
+
+	var seeAlso []string
+
+	if len(path) > 0 {
+		seeAlso = append(seeAlso, manPageName(app, path[:len(path)-1]))
+	}
+
+	if hasVisibleCommands(commands) {
+		page.WriteString(".SH COMMANDS\n")
+
+		for _, sub := range commands {
+			if sub.Hidden {
+				continue
+			}
+
+			subName := manPageName(app, append(append([]string{}, path...), sub.Name))
+			seeAlso = append(seeAlso, subName)
+
+			page.WriteString(".TP\n")
+			fmt.Fprintf(&page, "\\fB%s\\fR\n", manEscape(sub.Name))
+
+			if sub.Description != "" {
+				page.WriteString(manEscapeLines(sub.Description))
+
+				if sub.Alias != "" {
+					fmt.Fprintf(&page, " (Alias: %s)", manEscape(sub.Alias))
+				}
+
+				page.WriteString("\n")
+			}
+
+			fmt.Fprintf(&page, "See \\fB%s\\fR(%s).\n", manEscape(subName), ManSection)
+		}
+	}
+
+	if examples := commandExamples(app, cmd); len(examples) > 0 {
+		page.WriteString(".SH EXAMPLES\n")
+
+		for i, example := range examples {
+			if i > 0 {
+				page.WriteString(".PP\n")
+			}
+
+			if example.Description != "" {
+				page.WriteString(manEscapeLines(example.Description) + "\n.PP\n")
+			}
+
+			page.WriteString(".RS 4\n.nf\n")
+			fmt.Fprintf(&page, "$ %s\n", manEscape(example.CommandLine(app.UsageName)))
+			page.WriteString(".fi\n.RE\n")
+		}
+	}
+
+	var environment []parameters.Option
+	for _, group := range groups {
+		for _, opt := range group.Options {
+			if opt.EnvVar != "" {
+				environment = append(environment, opt)
+			}
+		}
+	}
+
+	if len(environment) > 0 {
+		page.WriteString(".SH ENVIRONMENT\n")
+
+		for _, opt := range environment {
+			page.WriteString(".TP\n")
+			fmt.Fprintf(&page, "\\fB%s\\fR\n", manEscape(opt.EnvVar))
+			fmt.Fprintf(&page, "Sets the value of %s.\n", describeManOption(opt))
+		}
+	}
+
+	if len(seeAlso) > 0 {
+		var refs []string
+		for _, ref := range seeAlso {
+			refs = append(refs, fmt.Sprintf("\\fB%s\\fR(%s)", manEscape(ref), ManSection))
+		}
+
+		page.WriteString(".SH SEE ALSO\n")
+		page.WriteString(strings.Join(refs, ", ") + "\n")
+	}
+
+	return ManPage{
+		Name:    name,
+		Content: page.String(),
+	}
+}
+
+// describeManSynopsis describes how to run a command, for the SYNOPSIS section of a man page. Names
+// that are typed as-is are shown in bold, and those that are replaced by values in italics.
+func describeManSynopsis(app *Application, cmd *Command, path []string, args []parameters.Argument, opts []parameters.Option, commands []*Command) string {
+	var words []string
+
+	for _, word := range describeSynopsis(app, cmd, path, args, opts, commands, DefaultWidth) {
+		var desc string
+
+		for _, part := range word {
+			switch part.style {
+			case synopsisLiteral:
+				desc += fmt.Sprintf("\\fB%s\\fR", manEscape(part.text))
+			case synopsisPlaceholder:
+				desc += fmt.Sprintf("\\fI%s\\fR", manEscape(part.text))
+			default:
+				desc += manEscape(part.text)
+			}
+		}
+
+		words = append(words, desc)
+	}
+
+	return strings.Join(words, " ") + "\n"
+}
+
+// describeManOption describes an option's names and value, e.g. `-d, --dsn=DSN`, formatted in bold
+// and italics.
+func describeManOption(opt parameters.Option) string {
+	return parameters.DescribeOptionKey(opt, func(text string, style parameters.OptionKeyStyle) string {
+		switch style {
+		case parameters.OptionKeyName:
+			return fmt.Sprintf("\\fB%s\\fR", manEscape(text))
+		case parameters.OptionKeyValue:
+			return fmt.Sprintf("\\fI%s\\fR", manEscape(text))
+		default:
+			return manEscape(text)
+		}
+	})
+}
+
+// hasVisibleCommands checks to see if any of the given commands are not hidden.
+func hasVisibleCommands(commands []*Command) bool {
+	for _, cmd := range commands {
+		if !cmd.Hidden {
+			return true
+		}
+	}
+
+	return false
+}
+
+// manPageName gets the name of the man page for the command at the given path.
+func manPageName(app *Application, path []string) string {
+	return strings.Join(append([]string{app.UsageName}, path...), "-")
+}
+
+// manParagraphs formats the given text as paragraphs, separated by blank lines. Indented
+// paragraphs (e.g. example commands) are shown as they are, without being filled.
+func manParagraphs(text string) string {
+	var result string
+
+	for i, paragraph := range strings.Split(strings.Trim(text, "\n"), "\n\n") {
+		if i > 0 {
+			result += ".PP\n"
+		}
+
+		if strings.HasPrefix(paragraph, " ") || strings.HasPrefix(paragraph, "\t") {
+			result += ".nf\n" + manEscapeLines(paragraph) + "\n.fi\n"
+			continue
+		}
+
+		result += manEscapeLines(strings.TrimSpace(paragraph)) + "\n"
+	}
+
+	return result
+}
+
+// manEscapeLines escapes text that may span multiple lines, so that no line is treated as a roff
+// request. Markup tags are removed.
+func manEscapeLines(text string) string {
+	lines := strings.Split(NewFormatter().Format(strings.Trim(text, "\n"), false), "\n")
+
+	for i, line := range lines {
+		lines[i] = manEscape(line)
+
+		if strings.HasPrefix(lines[i], ".") || strings.HasPrefix(lines[i], "'") {
+			lines[i] = "\\&" + lines[i]
+		}
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// manEscape escapes characters in the given text that have special meaning in roff.
+func manEscape(text string) string {
+	return strings.NewReplacer(`\`, `\e`, "-", `\-`).Replace(text)
+}
+
+// manQuote escapes and quotes the given text for use as an argument to a roff request.
+func manQuote(text string) string {
+	return `"` + strings.Replace(manEscape(text), `"`, `\(dq`, -1) + `"`
+}
@@ -0,0 +1,164 @@
+package console_test
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/seeruk/go-console"
+	"github.com/seeruk/go-console/parameters"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGenerateManPages(t *testing.T) {
+	createApplication := func() *console.Application {
+		var dsn string
+		var steps int
+
+		migrate := &console.Command{
+			Name:        "migrate",
+			Alias:       "m",
+			Description: "Run database migrations.",
+			Help:        "Migrations are run in order.\n\n  $ app db migrate 3",
+			Configure: func(definition *console.Definition) {
+				definition.AddOption(console.OptionDefinition{
+					Value:  parameters.NewStringValue(&dsn),
+					Spec:   "-d, --dsn=DSN",
+					Desc:   "The database DSN.",
+					EnvVar: "APP_DSN",
+				})
+
+				definition.AddArgument(console.ArgumentDefinition{
+					Value: parameters.NewIntValue(&steps),
+					Spec:  "[STEPS]",
+					Desc:  "The number of migrations to run.",
+				})
+			},
+			Execute: func(input *console.Input, output *console.Output) error {
+				return nil
+			},
+		}
+
+		db := &console.Command{
+			Name:        "db",
+			Description: "Manage the database.",
+		}
+
+		db.AddCommand(migrate)
+		db.AddCommand(&console.Command{Name: "secret", Hidden: true})
+
+		application := console.NewApplication("App", "1.2.3")
+		application.UsageName = "app"
+		application.Help = ".Dots at the start of lines are escaped."
+		application.AddCommand(db)
+
+		return application
+	}
+
+	findPage := func(pages []console.ManPage, name string) (console.ManPage, bool) {
+		for _, page := range pages {
+			if page.Name == name {
+				return page, true
+			}
+		}
+
+		return console.ManPage{}, false
+	}
+
+	t.Run("should generate a page for the application and each command", func(t *testing.T) {
+		pages := console.GenerateManPages(createApplication())
+
+		var names []string
+		for _, page := range pages {
+			names = append(names, page.FileName())
+		}
+
+		assert.Equal(t, []string{"app.1", "app-db.1", "app-db-migrate.1"}, names)
+	})
+
+	t.Run("should describe the application", func(t *testing.T) {
+		page, _ := findPage(console.GenerateManPages(createApplication()), "app")
+
+		assert.Contains(t, page.Content, ".TH \"APP\" 1 \"\" \"App 1.2.3\" \"General Commands Manual\"\n")
+		assert.Contains(t, page.Content, ".SH NAME\napp \\- App\n")
+		assert.Contains(t, page.Content, ".SH DESCRIPTION\n\\&.Dots at the start of lines are escaped.\n")
+		assert.Contains(t, page.Content, ".SH COMMANDS\n.TP\n\\fBdb\\fR\nManage the database.\n")
+		assert.Contains(t, page.Content, ".SH SEE ALSO\n\\fBapp\\-db\\fR(1)\n")
+	})
+
+	t.Run("should describe commands", func(t *testing.T) {
+		page, _ := findPage(console.GenerateManPages(createApplication()), "app-db-migrate")
+
+		assert.Contains(t, page.Content, ".SH NAME\napp\\-db\\-migrate \\- Run database migrations.\n")
+		assert.Contains(t, page.Content, ".SH SYNOPSIS\n\\fBapp\\fR \\fBdb\\fR \\fBmigrate\\fR [\\fB\\-h\\fR] [\\fB\\-\\-dsn\\fR=\\fIDSN\\fR] [\\fISTEPS\\fR]\n")
+		assert.Contains(t, page.Content, ".PP\nMigrations are run in order.\n.PP\n.nf\n  $ app db migrate 3\n.fi\n")
+		assert.Contains(t, page.Content, ".SH ARGUMENTS\n.TP\n\\fISTEPS\\fR\nThe number of migrations to run.\n")
+		assert.Contains(t, page.Content, ".TP\n\\fB\\-d\\fR, \\fB\\-\\-dsn\\fR=\\fIDSN\\fR\nThe database DSN.\n")
+		assert.Contains(t, page.Content, ".SH ENVIRONMENT\n.TP\n\\fBAPP_DSN\\fR\n")
+		assert.Contains(t, page.Content, ".SH SEE ALSO\n\\fBapp\\-db\\fR(1)\n")
+	})
+
+	t.Run("should refer to parent and sub-command pages", func(t *testing.T) {
+		page, _ := findPage(console.GenerateManPages(createApplication()), "app-db")
+
+		assert.Contains(t, page.Content, "Run database migrations. (Alias: m)\n")
+		assert.Contains(t, page.Content, ".SH SEE ALSO\n\\fBapp\\fR(1), \\fBapp\\-db\\-migrate\\fR(1)\n")
+		assert.NotContains(t, page.Content, "secret")
+	})
+
+	t.Run("should order and group options as help does", func(t *testing.T) {
+		var zone, apple, host string
+
+		application := console.NewApplication("App", "1.2.3")
+		application.UsageName = "app"
+		application.OptionOrder = parameters.OptionOrderDeclaration
+		application.AddCommand(&console.Command{
+			Name: "serve",
+			Configure: func(definition *console.Definition) {
+				definition.AddOption(console.OptionDefinition{
+					Value: parameters.NewStringValue(&host),
+					Spec:  "--host=HOST",
+					Desc:  "The host.",
+					Group: "Connection options",
+				})
+
+				definition.AddOption(console.OptionDefinition{
+					Value: parameters.NewStringValue(&zone),
+					Spec:  "--zone=ZONE",
+					Desc:  "The zone.",
+				})
+
+				definition.AddOption(console.OptionDefinition{
+					Value: parameters.NewStringValue(&apple),
+					Spec:  "--apple=APPLE",
+					Desc:  "The apple.",
+				})
+			},
+			Execute: func(input *console.Input, output *console.Output) error {
+				return nil
+			},
+		})
+
+		page, _ := findPage(console.GenerateManPages(application), "app-serve")
+
+		assert.True(t, strings.Index(page.Content, "zone") < strings.Index(page.Content, "apple"), "Expected --zone to come before --apple.")
+		assert.Contains(t, page.Content, ".SS Connection options\n.TP\n\\fB\\-\\-host\\fR=\\fIHOST\\fR\nThe host.\n")
+		assert.True(t, strings.Index(page.Content, "apple") < strings.Index(page.Content, ".SS Connection options"), "Expected grouped options to come last.")
+	})
+
+	t.Run("should write pages to files", func(t *testing.T) {
+		dir, err := ioutil.TempDir("", "go-console-man")
+		assert.NoError(t, err)
+
+		defer os.RemoveAll(dir)
+
+		err = console.WriteManPages(createApplication(), filepath.Join(dir, "man1"))
+		assert.NoError(t, err)
+
+		content, err := ioutil.ReadFile(filepath.Join(dir, "man1", "app-db-migrate.1"))
+		assert.NoError(t, err)
+		assert.Contains(t, string(content), ".SH NAME\n")
+	})
+}
@@ -0,0 +1,277 @@
+package console
+
+import (
+	"encoding/json"
+	"io"
+
+	"github.com/seeruk/go-console/parameters"
+)
+
+// ModelSchemaVersion is the version of the schema that models are described with. It is only
+// increased when a change is made that could break existing consumers (e.g. removing or renaming a
+// field), new fields may be added at any time.
+const ModelSchemaVersion = 1
+
+// modelCommandName is the name of the hidden command that writes the application's model as JSON.
+const modelCommandName = "__model"
+
+// ApplicationModel describes an Application, and everything that can be run with it, so that it
+// can be introspected by other tools (e.g. to generate documentation, or wrappers).
+type ApplicationModel struct {
+	// The version of the schema this model is described with, see ModelSchemaVersion.
+	SchemaVersion int `json:"schemaVersion"`
+	// The name of the application.
+	Name string `json:"name"`
+	// The name of the application used to run it.
+	UsageName string `json:"usageName"`
+	// The version of the application.
+	Version string `json:"version"`
+	// Help message for the application.
+	Help string `json:"help"`
+	// The global options, available to every command.
+	Options []OptionModel `json:"options"`
+	// The root command, that is run by default, or nil if there isn't one.
+	Root *CommandModel `json:"root"`
+	// The commands that can be run, including any enabled built-in commands.
+	Commands []CommandModel `json:"commands"`
+}
+
+// CommandModel describes a Command, including it's sub-commands.
+type CommandModel struct {
+	// The name of the command.
+	Name string `json:"name"`
+	// The path to the command from the application, including it's own name.
+	Path []string `json:"path"`
+	// Other names the command can be run with.
+	Aliases []string `json:"aliases"`
+	// The description of the command.
+	Description string `json:"description"`
+	// Help message for the command.
+	Help string `json:"help"`
+	// Whether or not the command is hidden from help and completion.
+	Hidden bool `json:"hidden"`
+	// The arguments defined by the command, in order.
+	Arguments []ArgumentModel `json:"arguments"`
+	// The options defined by the command, not including global options.
+	Options []OptionModel `json:"options"`
+	// Examples of how to run the command.
+	Examples []ExampleModel `json:"examples"`
+	// The sub-commands of the command.
+	Commands []CommandModel `json:"commands"`
+}
+
+// ExampleModel describes an Example.
+type ExampleModel struct {
+	// The arguments the application is run with.
+	Args []string `json:"args"`
+	// The full command line, including the application's usage name.
+	CommandLine string `json:"commandLine"`
+	// An explanation of what the example does.
+	Description string `json:"description"`
+}
+
+// ArgumentModel describes a parameters.Argument.
+type ArgumentModel struct {
+	// The name of the argument.
+	Name string `json:"name"`
+	// The description of the argument.
+	Description string `json:"description"`
+	// The type of the argument's value, see parameters.ValueType.
+	ValueType string `json:"valueType"`
+	// The default value of the argument, empty if it has none, or is secret.
+	Default string `json:"default"`
+	// Whether or not the argument is required.
+	Required bool `json:"required"`
+	// Whether or not the value of the argument is secret.
+	Secret bool `json:"secret"`
+}
+
+// OptionModel describes a parameters.Option.
+type OptionModel struct {
+	// The names of the option, without leading hyphens.
+	Names []string `json:"names"`
+	// The description of the option.
+	Description string `json:"description"`
+	// Whether the option takes a value, one of: none, optional, required.
+	ValueMode string `json:"valueMode"`
+	// The name of the option's value, shown in help.
+	ValueName string `json:"valueName"`
+	// The type of the option's value, see parameters.ValueType.
+	ValueType string `json:"valueType"`
+	// The environment variable the option's value may be read from.
+	EnvVar string `json:"envVar"`
+	// The values the option accepts, empty if it accepts any value.
+	Choices []string `json:"choices"`
+	// The name of the group the option is shown in, in help, empty if it isn't in a group.
+	Group string `json:"group"`
+	// The default value of the option, empty if it has none, or is secret.
+	Default string `json:"default"`
+	// Whether or not the option is required.
+	Required bool `json:"required"`
+	// Whether or not the value of the option is secret.
+	Secret bool `json:"secret"`
+}
+
+// DescribeModel describes an Application, and all of it's commands (including hidden commands), as
+// an ApplicationModel.
+func DescribeModel(app *Application) ApplicationModel {
+	definition := NewDefinition()
+
+	app.configure(definition)
+
+	model := ApplicationModel{
+		SchemaVersion: ModelSchemaVersion,
+		Name:          app.Name,
+		UsageName:     app.UsageName,
+		Version:       app.Version,
+		Help:          app.Help,
+		Options:       describeOptionModels(definition.Options()),
+		Commands:      describeCommandModels(app, nil, app.Commands()),
+	}
+
+	if app.rootCommand != nil {
+		root := describeCommandModel(app, nil, app.rootCommand)
+		model.Root = &root
+	}
+
+	return model
+}
+
+// WriteModel describes an Application as an ApplicationModel (see DescribeModel), and writes it to
+// the given writer as indented JSON.
+func WriteModel(writer io.Writer, app *Application) error {
+	encoder := json.NewEncoder(writer)
+	encoder.SetIndent("", "  ")
+
+	return encoder.Encode(DescribeModel(app))
+}
+
+// describeCommandModels describes each of the given commands, found at the given path.
+func describeCommandModels(app *Application, path []string, commands []*Command) []CommandModel {
+	models := []CommandModel{}
+
+	for _, cmd := range commands {
+		models = append(models, describeCommandModel(app, append(append([]string{}, path...), cmd.Name), cmd))
+	}
+
+	return models
+}
+
+// describeCommandModel describes the given command, found at the given path. The root command has
+// an empty path.
+func describeCommandModel(app *Application, path []string, cmd *Command) CommandModel {
+	definition := NewDefinition()
+
+	if cmd.Configure != nil {
+		cmd.Configure(definition)
+	}
+
+	aliases := []string{}
+	if cmd.Alias != "" {
+		aliases = append(aliases, cmd.Alias)
+	}
+
+	if path == nil {
+		path = []string{}
+	}
+
+	return CommandModel{
+		Name:        cmd.Name,
+		Path:        path,
+		Aliases:     aliases,
+		Description: cmd.Description,
+		Help:        cmd.Help,
+		Hidden:      cmd.Hidden,
+		Arguments:   describeArgumentModels(definition.Arguments()),
+		Options:     describeOptionModels(definition.Options()),
+		Examples:    describeExampleModels(app, cmd.Examples),
+		Commands:    describeCommandModels(app, path, cmd.Commands()),
+	}
+}
+
+// describeExampleModels describes each of the given examples.
+func describeExampleModels(app *Application, examples []Example) []ExampleModel {
+	models := []ExampleModel{}
+
+	for _, example := range examples {
+		args := append([]string{}, example.Args...)
+
+		models = append(models, ExampleModel{
+			Args:        args,
+			CommandLine: example.CommandLine(app.UsageName),
+			Description: example.Description,
+		})
+	}
+
+	return models
+}
+
+// describeArgumentModels describes each of the given arguments.
+func describeArgumentModels(arguments []parameters.Argument) []ArgumentModel {
+	models := []ArgumentModel{}
+
+	for _, arg := range arguments {
+		var def string
+		if !arg.Secret {
+			def = arg.Default
+		}
+
+		models = append(models, ArgumentModel{
+			Name:        arg.Name,
+			Description: arg.Description,
+			ValueType:   parameters.ValueType(arg.Value),
+			Default:     def,
+			Required:    arg.Required,
+			Secret:      arg.Secret,
+		})
+	}
+
+	return models
+}
+
+// describeOptionModels describes each of the given options.
+func describeOptionModels(options []parameters.Option) []OptionModel {
+	models := []OptionModel{}
+
+	for _, opt := range options {
+		models = append(models, OptionModel{
+			Names:       append([]string{}, opt.Names...),
+			Description: opt.Description,
+			ValueMode:   describeValueMode(opt.ValueMode),
+			ValueName:   opt.ValueName,
+			ValueType:   parameters.ValueType(opt.Value),
+			EnvVar:      opt.EnvVar,
+			Choices:     append([]string{}, opt.Choices...),
+			Group:       opt.Group,
+			Default:     optionDefault(opt),
+			Required:    opt.Required,
+			Secret:      opt.Secret,
+		})
+	}
+
+	return models
+}
+
+// describeValueMode describes an option's value mode, as one of: none, optional, required.
+func describeValueMode(mode parameters.OptionValueMode) string {
+	switch mode {
+	case parameters.OptionValueOptional:
+		return "optional"
+	case parameters.OptionValueRequired:
+		return "required"
+	}
+
+	return "none"
+}
+
+// newModelCommand creates the hidden command that writes the application's model as JSON.
+func newModelCommand(app *Application) *Command {
+	return &Command{
+		Name:        modelCommandName,
+		Description: "Write a description of the application as JSON.",
+		Hidden:      true,
+		Execute: func(input *Input, output *Output) error {
+			return WriteModel(output.Writer, app)
+		},
+	}
+}
@@ -0,0 +1,193 @@
+package console_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"testing"
+
+	"github.com/seeruk/go-console"
+	"github.com/seeruk/go-console/parameters"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDescribeModel(t *testing.T) {
+	createApplication := func() *console.Application {
+		dsn := "localhost"
+		token := "hunter2"
+
+		var steps int
+
+		migrate := &console.Command{
+			Name:        "migrate",
+			Alias:       "m",
+			Description: "Run database migrations.",
+			Configure: func(definition *console.Definition) {
+				definition.AddOption(console.OptionDefinition{
+					Value:    parameters.NewStringValue(&dsn),
+					Spec:     "-d, --dsn=DSN",
+					Desc:     "The database DSN.",
+					EnvVar:   "APP_DSN",
+					Required: true,
+				})
+
+				definition.AddOption(console.OptionDefinition{
+					Value:  parameters.NewStringValue(&token),
+					Spec:   "--token=TOKEN",
+					Secret: true,
+				})
+
+				definition.AddArgument(console.ArgumentDefinition{
+					Value: parameters.NewIntValue(&steps),
+					Spec:  "[STEPS]",
+					Desc:  "The number of migrations to run.",
+				})
+			},
+			Execute: func(input *console.Input, output *console.Output) error {
+				return nil
+			},
+		}
+
+		db := &console.Command{
+			Name:        "db",
+			Description: "Manage the database.",
+		}
+
+		db.AddCommand(migrate)
+		db.AddCommand(&console.Command{Name: "secret", Hidden: true})
+
+		application := console.NewApplication("App", "1.2.3")
+		application.UsageName = "app"
+		application.AddCommand(db)
+
+		return application
+	}
+
+	t.Run("should describe the application", func(t *testing.T) {
+		model := console.DescribeModel(createApplication())
+
+		assert.Equal(t, console.ModelSchemaVersion, model.SchemaVersion)
+		assert.Equal(t, "App", model.Name)
+		assert.Equal(t, "app", model.UsageName)
+		assert.Equal(t, "1.2.3", model.Version)
+		assert.Nil(t, model.Root)
+		assert.Len(t, model.Options, 1)
+		assert.Equal(t, []string{"h", "help"}, model.Options[0].Names)
+		assert.Equal(t, "bool", model.Options[0].ValueType)
+		assert.Equal(t, "none", model.Options[0].ValueMode)
+	})
+
+	t.Run("should describe commands, including hidden commands", func(t *testing.T) {
+		model := console.DescribeModel(createApplication())
+
+		assert.Len(t, model.Commands, 1)
+
+		db := model.Commands[0]
+		assert.Equal(t, "db", db.Name)
+		assert.Equal(t, []string{"db"}, db.Path)
+		assert.Equal(t, []string{}, db.Aliases)
+		assert.Len(t, db.Commands, 2)
+
+		migrate := db.Commands[0]
+		assert.Equal(t, []string{"db", "migrate"}, migrate.Path)
+		assert.Equal(t, []string{"m"}, migrate.Aliases)
+		assert.Equal(t, "Run database migrations.", migrate.Description)
+		assert.False(t, migrate.Hidden)
+
+		assert.True(t, db.Commands[1].Hidden)
+	})
+
+	t.Run("should describe arguments and options", func(t *testing.T) {
+		model := console.DescribeModel(createApplication())
+		migrate := model.Commands[0].Commands[0]
+
+		assert.Equal(t, []console.ArgumentModel{
+			{
+				Name:        "STEPS",
+				Description: "The number of migrations to run.",
+				ValueType:   "int",
+			},
+		}, migrate.Arguments)
+
+		assert.Equal(t, []console.OptionModel{
+			{
+				Names:       []string{"d", "dsn"},
+				Description: "The database DSN.",
+				ValueMode:   "required",
+				ValueName:   "DSN",
+				ValueType:   "string",
+				EnvVar:      "APP_DSN",
+				Choices:     []string{},
+				Default:     "localhost",
+				Required:    true,
+			},
+			{
+				Names:     []string{"token"},
+				ValueMode: "required",
+				ValueName: "TOKEN",
+				ValueType: "string",
+				Choices:   []string{},
+				Secret:    true,
+			},
+		}, migrate.Options)
+	})
+
+	t.Run("should describe the root command", func(t *testing.T) {
+		application := createApplication()
+		application.SetRootCommand(&console.Command{Description: "Do the default thing."})
+
+		model := console.DescribeModel(application)
+
+		if assert.NotNil(t, model.Root) {
+			assert.Equal(t, "Do the default thing.", model.Root.Description)
+			assert.Equal(t, []string{}, model.Root.Path)
+		}
+	})
+}
+
+func TestWriteModel(t *testing.T) {
+	t.Run("should write the model as JSON", func(t *testing.T) {
+		application := console.NewApplication("App", "1.2.3")
+		application.UsageName = "app"
+		application.AddCommand(&console.Command{Name: "db"})
+
+		buf := bytes.Buffer{}
+
+		err := console.WriteModel(&buf, application)
+		assert.NoError(t, err)
+
+		var decoded map[string]interface{}
+
+		assert.NoError(t, json.Unmarshal(buf.Bytes(), &decoded))
+		assert.Equal(t, float64(console.ModelSchemaVersion), decoded["schemaVersion"])
+		assert.Equal(t, "app", decoded["usageName"])
+		assert.Nil(t, decoded["root"])
+		assert.Contains(t, buf.String(), "\n  \"commands\": [\n")
+		assert.Contains(t, buf.String(), "\"aliases\": [],\n")
+	})
+
+	t.Run("should be written by the hidden model command, if enabled", func(t *testing.T) {
+		buf := bytes.Buffer{}
+
+		createApplication := func() *console.Application {
+			application := console.NewApplication("App", "1.2.3")
+			application.UsageName = "app"
+			application.EnableModelCommand = true
+			application.Writer = &buf
+			application.ErrWriter = ioutil.Discard
+
+			return application
+		}
+
+		code := createApplication().Run([]string{"__model"}, []string{})
+		assert.Equal(t, 0, code)
+		assert.Contains(t, buf.String(), "\"usageName\": \"app\"")
+		assert.Contains(t, buf.String(), "\"name\": \"__model\"")
+		assert.Contains(t, buf.String(), "\"hidden\": true")
+
+		buf.Reset()
+
+		createApplication().Run([]string{"--help"}, []string{})
+		assert.NotContains(t, buf.String(), "__model")
+	})
+}
@@ -3,43 +3,162 @@ package console
 import (
 	"fmt"
 	"io"
+	"os"
+	"sync"
 )
 
 // Output abstracts application output. This is mainly useful for testing, as a different writer can
-// be passed to capture output in an easy to test manner.
+// be passed to capture output in an easy to test manner. Output has separate writers for regular
+// output, and for errors and diagnostic messages (i.e. stdout and stderr).
+//
+// Styled output (e.g. Styledf), errors, and level-gated output (e.g. Verbosef) may contain markup
+// tags (e.g. `<info>done</info>`) which are rendered using the Output's Formatter. Whether or not
+// colour is used is decided separately for each writer. Print, Printf, and Println never render
+// markup, so they write exactly what they're given.
 type Output struct {
-	Writer   io.Writer
-	exitCode int
+	Writer     io.Writer
+	ErrWriter  io.Writer
+	exitCode   int
+	verbosity  Verbosity
+	formatter  *Formatter
+	colorMode  ColorMode
+	env        []string
+	format     string
+	formats    []string
+	progress   *Progress
+	progressMu sync.Mutex
+	width      int
 }
 
-// NewOutput creates a new Output.
+// NewOutput creates a new Output. Errors are written to the same writer as regular output, unless
+// ErrWriter is changed.
 func NewOutput(writer io.Writer) *Output {
 	return &Output{
-		Writer:   writer,
-		exitCode: 0,
+		Writer:    writer,
+		ErrWriter: writer,
+		exitCode:  0,
+		verbosity: VerbosityNormal,
+		formatter: NewFormatter(),
+		colorMode: ColorModeAuto,
+		env:       os.Environ(),
 	}
 }
 
 // Print uses the fmt package's Print with a pre-set writer. Spaces are always added between
 // operands. It returns the number of bytes written and any write error encountered.
 func (o *Output) Print(a ...interface{}) (int, error) {
-	return fmt.Fprint(o.Writer, a...)
+	return o.writeOut(fmt.Sprint(a...))
 }
 
 // Printf uses the fmt package's Printf with a pre-set writer. It returns the number of bytes
 // written and any write error encountered.
 func (o *Output) Printf(format string, a ...interface{}) (int, error) {
-	return fmt.Fprintf(o.Writer, format, a...)
+	return o.writeOut(fmt.Sprintf(format, a...))
 }
 
 // Println uses the fmt package's Println with a pre-set writer. Spaces are always added between
 // operands and a newline is appended. It returns the number of bytes written and any write error
 // encountered.
 func (o *Output) Println(a ...interface{}) (int, error) {
-	return fmt.Fprintln(o.Writer, a...)
+	return o.writeOut(fmt.Sprintln(a...))
+}
+
+// Styled is like Print, but renders markup tags. It returns the number of bytes written once the
+// markup is rendered, and any write error encountered.
+func (o *Output) Styled(a ...interface{}) (int, error) {
+	return o.writeOut(o.markup(o.Writer, fmt.Sprint(a...)))
+}
+
+// Styledf is like Printf, but renders markup tags. It returns the number of bytes written once the
+// markup is rendered, and any write error encountered.
+func (o *Output) Styledf(format string, a ...interface{}) (int, error) {
+	return o.writeOut(o.markup(o.Writer, fmt.Sprintf(format, a...)))
+}
+
+// Styledln is like Println, but renders markup tags. It returns the number of bytes written once
+// the markup is rendered, and any write error encountered.
+func (o *Output) Styledln(a ...interface{}) (int, error) {
+	return o.writeOut(o.markup(o.Writer, fmt.Sprintln(a...)))
+}
+
+// Errorf uses the fmt package's Printf with a pre-set error writer. It returns the number of bytes
+// written and any write error encountered.
+func (o *Output) Errorf(format string, a ...interface{}) (int, error) {
+	return o.write(o.ErrWriter, fmt.Sprintf(format, a...))
+}
+
+// Errorln uses the fmt package's Println with a pre-set error writer. Spaces are always added
+// between operands and a newline is appended. It returns the number of bytes written and any write
+// error encountered.
+func (o *Output) Errorln(a ...interface{}) (int, error) {
+	return o.write(o.ErrWriter, fmt.Sprintln(a...))
 }
 
 // SetExitCode sets the exit code to a specific int. By default, the exit code is set to 0.
 func (o *Output) SetExitCode(code int) {
 	o.exitCode = code
 }
+
+// Formatter gets the Formatter used to render markup in output, allowing custom styles to be
+// registered.
+func (o *Output) Formatter() *Formatter {
+	return o.formatter
+}
+
+// SetColorMode sets when coloured output should be used. By default, ColorModeAuto is used.
+func (o *Output) SetColorMode(mode ColorMode) {
+	o.colorMode = mode
+}
+
+// Width gets the width that output should be wrapped to. Unless a width has been set, it is found
+// using the COLUMNS environment variable, or the size of the terminal being written to. The width is
+// always between MinWidth and MaxWidth.
+func (o *Output) Width() int {
+	return o.widthFor(o.Writer)
+}
+
+// widthFor gets the width that output written to the given writer should be wrapped to.
+func (o *Output) widthFor(writer io.Writer) int {
+	return detectWidth(o.width, writer, o.env)
+}
+
+// SetWidth sets the width that output should be wrapped to, instead of detecting it. If width is 0,
+// the width is detected again.
+func (o *Output) SetWidth(width int) {
+	o.width = width
+}
+
+// IsDecorated checks to see if output written to the given writer will be coloured.
+func (o *Output) IsDecorated(writer io.Writer) bool {
+	return shouldColorize(o.colorMode, writer, o.env)
+}
+
+// markup renders markup in the given text, to be written to the given writer.
+func (o *Output) markup(writer io.Writer, text string) string {
+	return o.formatter.Format(text, o.IsDecorated(writer))
+}
+
+// write renders markup in the given text, and writes it to the given writer.
+func (o *Output) write(writer io.Writer, text string) (int, error) {
+	return io.WriteString(writer, o.markup(writer, text))
+}
+
+// writeOut writes the given text to the regular output writer as-is, unless the verbosity is
+// quiet. If there are progress bars being shown, the text is written above them.
+func (o *Output) writeOut(text string) (int, error) {
+	if o.IsQuiet() {
+		return 0, nil
+	}
+
+	return o.writeText(text)
+}
+
+// writeText writes the given text to the regular output writer as-is, whatever the verbosity. If
+// there are progress bars being shown, the text is written above them.
+func (o *Output) writeText(text string) (int, error) {
+	if progress := o.activeProgress(); progress != nil {
+		return progress.interleave(o.Writer, text)
+	}
+
+	return io.WriteString(o.Writer, text)
+}
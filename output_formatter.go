@@ -0,0 +1,423 @@
+package console
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// Colours that may be used in a Style.
+const (
+	ColorDefault Color = iota
+	ColorBlack
+	ColorRed
+	ColorGreen
+	ColorYellow
+	ColorBlue
+	ColorMagenta
+	ColorCyan
+	ColorWhite
+)
+
+// Color represents one of the standard terminal colours.
+type Color int
+
+// Colour modes.
+const (
+	ColorModeAuto ColorMode = iota
+	ColorModeAlways
+	ColorModeNever
+)
+
+// ColorMode represents when coloured (decorated) output should be used. ColorMode implements
+// parameters.Value so that it can be used as an option value directly.
+type ColorMode int
+
+// Set assigns a value to this ColorMode, accepting "auto", "always", or "never".
+func (m *ColorMode) Set(s string) error {
+	switch s {
+	case "auto":
+		*m = ColorModeAuto
+	case "always":
+		*m = ColorModeAlways
+	case "never":
+		*m = ColorModeNever
+	default:
+		return fmt.Errorf("invalid colour mode '%s', expected one of: auto, always, never", s)
+	}
+
+	return nil
+}
+
+// String converts this ColorMode to a string.
+func (m *ColorMode) String() string {
+	switch *m {
+	case ColorModeAlways:
+		return "always"
+	case ColorModeNever:
+		return "never"
+	}
+
+	return "auto"
+}
+
+// Style represents how text inside of a markup tag should be rendered.
+type Style struct {
+	Foreground Color
+	Background Color
+	Bold       bool
+	Dim        bool
+	Italic     bool
+	Underline  bool
+}
+
+// sequence produces the ANSI escape sequence that applies this Style.
+func (s Style) sequence() string {
+	var codes []string
+
+	if s.Bold {
+		codes = append(codes, "1")
+	}
+
+	if s.Dim {
+		codes = append(codes, "2")
+	}
+
+	if s.Italic {
+		codes = append(codes, "3")
+	}
+
+	if s.Underline {
+		codes = append(codes, "4")
+	}
+
+	if s.Foreground != ColorDefault {
+		codes = append(codes, strconv.Itoa(29+int(s.Foreground)))
+	}
+
+	if s.Background != ColorDefault {
+		codes = append(codes, strconv.Itoa(39+int(s.Background)))
+	}
+
+	if len(codes) == 0 {
+		return ""
+	}
+
+	return "\x1b[" + strings.Join(codes, ";") + "m"
+}
+
+// ansiReset is the ANSI escape sequence that resets all styles.
+const ansiReset = "\x1b[0m"
+
+// Formatter renders text containing markup tags, like `<error>failed</error>`, either to ANSI
+// escape sequences, or to plain text with the tags stripped. Only tags with a registered Style are
+// treated as markup, anything else is left as-is. A literal tag can be written by escaping the
+// opening bracket (e.g. `\<error>`). The `</>` tag closes the most recently opened tag.
+type Formatter struct {
+	styles map[string]Style
+}
+
+// NewFormatter creates a new Formatter with the default styles registered (error, warning, info,
+// comment, and question).
+func NewFormatter() *Formatter {
+	return &Formatter{
+		styles: map[string]Style{
+			"error":    {Foreground: ColorWhite, Background: ColorRed},
+			"warning":  {Foreground: ColorBlack, Background: ColorYellow},
+			"info":     {Foreground: ColorGreen},
+			"comment":  {Foreground: ColorYellow},
+			"question": {Foreground: ColorBlack, Background: ColorCyan},
+		},
+	}
+}
+
+// SetStyle registers a Style with the given name, to be used as a markup tag. Existing styles with
+// the same name are replaced.
+func (f *Formatter) SetStyle(name string, style Style) {
+	f.styles[name] = style
+}
+
+// Format renders the markup in the given text. If decorated is true, tags are rendered to ANSI
+// escape sequences, otherwise they are stripped.
+func (f *Formatter) Format(text string, decorated bool) string {
+	var result strings.Builder
+	var stack []Style
+
+	for _, token := range f.tokenize(text) {
+		switch {
+		case !token.isTag:
+			result.WriteString(token.text)
+		case token.isClose:
+			stack = stack[:len(stack)-1]
+
+			if !decorated {
+				continue
+			}
+
+			// Reset, then apply each style still on the stack, in order.
+			result.WriteString(ansiReset)
+
+			for _, style := range stack {
+				result.WriteString(style.sequence())
+			}
+		default:
+			style := f.styles[token.tag]
+			stack = append(stack, style)
+
+			if decorated {
+				result.WriteString(style.sequence())
+			}
+		}
+	}
+
+	if decorated && len(stack) > 0 {
+		result.WriteString(ansiReset)
+	}
+
+	return result.String()
+}
+
+// markupToken is a part of some text containing markup, either a tag, or some plain text.
+type markupToken struct {
+	// The raw text of the token, as it appeared in the input.
+	raw string
+	// The text of the token as it is displayed, empty for tags.
+	text string
+	// The name of the tag, if this token is an opening tag.
+	tag string
+	// Whether or not this token is a tag.
+	isTag bool
+	// Whether or not this token is a closing tag.
+	isClose bool
+}
+
+// tokenize splits the given text into tags and plain text. Closing tags are only treated as tags
+// if there is a tag open for them to close.
+func (f *Formatter) tokenize(text string) []markupToken {
+	var tokens []markupToken
+	var plain strings.Builder
+	var plainRaw strings.Builder
+	var depth int
+
+	flush := func() {
+		if plainRaw.Len() > 0 {
+			tokens = append(tokens, markupToken{raw: plainRaw.String(), text: plain.String()})
+			plain.Reset()
+			plainRaw.Reset()
+		}
+	}
+
+	for i := 0; i < len(text); i++ {
+		if text[i] == '\\' && i+1 < len(text) && text[i+1] == '<' {
+			plain.WriteByte('<')
+			plainRaw.WriteString("\\<")
+			i++
+			continue
+		}
+
+		end := -1
+		if text[i] == '<' {
+			end = strings.IndexByte(text[i:], '>')
+		}
+
+		if end != -1 {
+			raw := text[i : i+end+1]
+			tag := text[i+1 : i+end]
+			name := strings.TrimPrefix(tag, "/")
+			_, known := f.styles[name]
+
+			isClose := strings.HasPrefix(tag, "/") && (name == "" || known) && depth > 0
+			isOpen := !strings.HasPrefix(tag, "/") && known
+
+			if isClose || isOpen {
+				flush()
+
+				if isOpen {
+					depth++
+					tokens = append(tokens, markupToken{raw: raw, tag: name, isTag: true})
+				} else {
+					depth--
+					tokens = append(tokens, markupToken{raw: raw, isTag: true, isClose: true})
+				}
+
+				i += end
+				continue
+			}
+		}
+
+		plain.WriteByte(text[i])
+		plainRaw.WriteByte(text[i])
+	}
+
+	flush()
+
+	return tokens
+}
+
+// EscapeMarkup escapes the given text so that it is never treated as markup by a Formatter.
+func EscapeMarkup(text string) string {
+	return strings.Replace(text, "<", "\\<", -1)
+}
+
+// shouldColorize decides whether or not output written to the given writer should be coloured,
+// based on the colour mode, the NO_COLOR and FORCE_COLOR environment variables, and whether or not
+// the writer is a terminal.
+func shouldColorize(mode ColorMode, writer io.Writer, env []string) bool {
+	switch mode {
+	case ColorModeAlways:
+		return true
+	case ColorModeNever:
+		return false
+	}
+
+	if lookupEnv(env, "NO_COLOR") != "" {
+		return false
+	}
+
+	if force := lookupEnv(env, "FORCE_COLOR"); force != "" && force != "0" && force != "false" {
+		return true
+	}
+
+	return isTerminal(writer)
+}
+
+// lookupEnv finds the value of the environment variable with the given name in the given
+// environment, in the same format as os.Environ.
+func lookupEnv(env []string, name string) string {
+	for _, ev := range env {
+		pair := strings.SplitN(ev, "=", 2)
+
+		if pair[0] == name && len(pair) == 2 {
+			return pair[1]
+		}
+	}
+
+	return ""
+}
+
+// layoutUnit is a single unit of text with markup being laid out, either a tag or a single rune.
+type layoutUnit struct {
+	raw     string
+	width   int
+	isTag   bool
+	isClose bool
+	space   bool
+	newline bool
+}
+
+// layout splits the given text containing markup into lines no wider than the given width (if the
+// width is greater than 0). Lines are broken at spaces where possible. If truncate is true, only one
+// line is produced, and it is truncated with an ellipsis if necessary. Tags that are open at the end
+// of a line are closed, and re-opened at the start of the next line, so that each line can be
+// rendered on it's own.
+func (f *Formatter) layout(text string, width int, truncate bool) []string {
+	var units []layoutUnit
+
+	for _, token := range f.tokenize(text) {
+		if token.isTag {
+			units = append(units, layoutUnit{raw: token.raw, isTag: true, isClose: token.isClose})
+			continue
+		}
+
+		for _, r := range token.text {
+			if truncate && r == '\n' {
+				r = ' '
+			}
+
+			units = append(units, layoutUnit{
+				raw:     EscapeMarkup(string(r)),
+				width:   runeWidth(r),
+				space:   r == ' ',
+				newline: r == '\n',
+			})
+		}
+	}
+
+	var lines []string
+
+	// finish adds the given units as a line, closing any open tags. The tags that were open are
+	// returned so that they can be re-opened at the start of the next line.
+	finish := func(units []layoutUnit) []layoutUnit {
+		var open []layoutUnit
+		var raw strings.Builder
+
+		for _, unit := range units {
+			raw.WriteString(unit.raw)
+
+			if unit.isTag && unit.isClose && len(open) > 0 {
+				open = open[:len(open)-1]
+			} else if unit.isTag && !unit.isClose {
+				open = append(open, unit)
+			}
+		}
+
+		raw.WriteString(strings.Repeat("</>", len(open)))
+		lines = append(lines, raw.String())
+
+		return open
+	}
+
+	var line []layoutUnit
+	var lineWidth int
+
+	lastSpace := -1
+
+	for _, unit := range units {
+		if unit.newline {
+			line = finish(line)
+			lineWidth = 0
+			lastSpace = -1
+			continue
+		}
+
+		if width > 0 && !unit.isTag && lineWidth > 0 && lineWidth+unit.width > width {
+			if truncate {
+				// Remove text from the end of the line until the ellipsis fits.
+				for i := len(line) - 1; i >= 0 && lineWidth+1 > width; i-- {
+					if !line[i].isTag {
+						lineWidth -= line[i].width
+						line = append(line[:i], line[i+1:]...)
+					}
+				}
+
+				finish(append(line, layoutUnit{raw: "…", width: 1}))
+
+				return lines
+			}
+
+			if unit.space {
+				// Spaces at the point the line is broken are dropped.
+				line = finish(line)
+				lineWidth = 0
+				lastSpace = -1
+				continue
+			}
+
+			if lastSpace >= 0 {
+				rest := append([]layoutUnit{}, line[lastSpace+1:]...)
+				line = append(finish(line[:lastSpace]), rest...)
+			} else {
+				line = finish(line)
+			}
+
+			lineWidth = 0
+			lastSpace = -1
+
+			for _, u := range line {
+				lineWidth += u.width
+			}
+		}
+
+		if unit.space {
+			lastSpace = len(line)
+		}
+
+		line = append(line, unit)
+		lineWidth += unit.width
+	}
+
+	finish(line)
+
+	return lines
+}
@@ -0,0 +1,153 @@
+package console_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/seeruk/go-console"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFormatter(t *testing.T) {
+	t.Run("Format()", func(t *testing.T) {
+		t.Run("should strip tags if not decorated", func(t *testing.T) {
+			formatter := console.NewFormatter()
+
+			result := formatter.Format("<error>failed</error> and <info>ok</>", false)
+
+			assert.Equal(t, "failed and ok", result)
+		})
+
+		t.Run("should render tags to ANSI escapes if decorated", func(t *testing.T) {
+			formatter := console.NewFormatter()
+
+			result := formatter.Format("<info>ok</info>!", true)
+
+			assert.Equal(t, "\x1b[32mok\x1b[0m!", result)
+		})
+
+		t.Run("should restore outer styles when closing nested tags", func(t *testing.T) {
+			formatter := console.NewFormatter()
+
+			result := formatter.Format("<info>a<comment>b</comment>c</info>", true)
+
+			assert.Equal(t, "\x1b[32ma\x1b[33mb\x1b[0m\x1b[32mc\x1b[0m", result)
+		})
+
+		t.Run("should leave unknown tags as-is", func(t *testing.T) {
+			formatter := console.NewFormatter()
+
+			result := formatter.Format("<foo>bar</foo> 1 < 2", false)
+
+			assert.Equal(t, "<foo>bar</foo> 1 < 2", result)
+		})
+
+		t.Run("should allow tags to be escaped", func(t *testing.T) {
+			formatter := console.NewFormatter()
+
+			result := formatter.Format(console.EscapeMarkup("<info>literal</info>"), true)
+
+			assert.Equal(t, "<info>literal</info>", result)
+		})
+
+		t.Run("should support custom styles", func(t *testing.T) {
+			formatter := console.NewFormatter()
+			formatter.SetStyle("heading", console.Style{Bold: true, Underline: true, Foreground: console.ColorBlue})
+
+			assert.Equal(t, "\x1b[1;4;34mhi\x1b[0m", formatter.Format("<heading>hi</heading>", true))
+			assert.Equal(t, "hi", formatter.Format("<heading>hi</heading>", false))
+		})
+
+		t.Run("should reset styles left open at the end", func(t *testing.T) {
+			formatter := console.NewFormatter()
+
+			result := formatter.Format("<info>ok", true)
+
+			assert.Equal(t, "\x1b[32mok\x1b[0m", result)
+		})
+	})
+}
+
+func TestColorMode(t *testing.T) {
+	t.Run("Set()", func(t *testing.T) {
+		t.Run("should accept valid modes", func(t *testing.T) {
+			var mode console.ColorMode
+
+			assert.NoError(t, mode.Set("always"))
+			assert.Equal(t, console.ColorModeAlways, mode)
+			assert.Equal(t, "always", mode.String())
+
+			assert.NoError(t, mode.Set("never"))
+			assert.Equal(t, console.ColorModeNever, mode)
+
+			assert.NoError(t, mode.Set("auto"))
+			assert.Equal(t, console.ColorModeAuto, mode)
+		})
+
+		t.Run("should error for invalid modes", func(t *testing.T) {
+			var mode console.ColorMode
+
+			assert.Error(t, mode.Set("sometimes"))
+		})
+	})
+}
+
+func TestApplicationColor(t *testing.T) {
+	run := func(args []string, env []string) (string, int) {
+		writer := bytes.Buffer{}
+
+		application := console.NewApplication("seeruk/go-console", "1.2.3+testing")
+		application.Writer = &writer
+		application.ErrWriter = &writer
+		application.EnableColorOption = true
+		application.SetStyle("fancy", console.Style{Bold: true})
+		application.AddCommand(&console.Command{
+			Name: "test",
+			Execute: func(input *console.Input, output *console.Output) error {
+				output.Styled("<fancy>hello</fancy>")
+				return nil
+			},
+		})
+
+		code := application.Run(append([]string{"test"}, args...), env)
+
+		return writer.String(), code
+	}
+
+	t.Run("should not use colour when output is not a terminal", func(t *testing.T) {
+		output, _ := run([]string{}, []string{})
+
+		assert.Equal(t, "hello", output)
+	})
+
+	t.Run("should use colour if the color option is always", func(t *testing.T) {
+		output, _ := run([]string{"--color=always"}, []string{"NO_COLOR=1"})
+
+		assert.Equal(t, "\x1b[1mhello\x1b[0m", output)
+	})
+
+	t.Run("should not use colour if the color option is never", func(t *testing.T) {
+		output, _ := run([]string{"--color", "never"}, []string{"FORCE_COLOR=1"})
+
+		assert.Equal(t, "hello", output)
+	})
+
+	t.Run("should use colour if FORCE_COLOR is set", func(t *testing.T) {
+		output, _ := run([]string{}, []string{"FORCE_COLOR=1"})
+
+		assert.Equal(t, "\x1b[1mhello\x1b[0m", output)
+	})
+
+	t.Run("should not use colour if NO_COLOR is set", func(t *testing.T) {
+		output, _ := run([]string{}, []string{"NO_COLOR=1", "FORCE_COLOR=1"})
+
+		assert.Equal(t, "hello", output)
+	})
+
+	t.Run("should fail if the color option is invalid", func(t *testing.T) {
+		output, code := run([]string{"--color=sometimes"}, []string{})
+
+		assert.Equal(t, 101, code)
+		assert.Contains(t, output, "Expected one of: auto, always, never")
+	})
+}
@@ -0,0 +1,240 @@
+package console
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"text/template"
+
+	"gopkg.in/yaml.v2"
+)
+
+// Output formats, used by Render.
+const (
+	FormatTable    = "table"
+	FormatText     = "text"
+	FormatJSON     = "json"
+	FormatYAML     = "yaml"
+	FormatCSV      = "csv"
+	FormatTemplate = "template"
+)
+
+// DefaultFormats are the output formats supported by Render if no others have been specified. The
+// first format is the default.
+var DefaultFormats = []string{FormatTable, FormatText, FormatJSON, FormatYAML, FormatCSV, FormatTemplate}
+
+// Format gets the format that Render will use. If no format has been set, the first of the
+// supported formats is used.
+func (o *Output) Format() string {
+	if o.format != "" {
+		return o.format
+	}
+
+	return o.supportedFormats()[0]
+}
+
+// SetFormat sets the format that Render will use. The format must be one of the supported formats.
+// Template formats take a Go template as an argument, e.g. `template={{.Name}}`.
+func (o *Output) SetFormat(format string) error {
+	name, _ := splitFormat(format)
+
+	for _, supported := range o.supportedFormats() {
+		if name == supported {
+			o.format = format
+			return nil
+		}
+	}
+
+	return fmt.Errorf(
+		"unsupported output format '%s', expected one of: %s",
+		name,
+		strings.Join(o.supportedFormats(), ", "),
+	)
+}
+
+// SetFormats sets the formats that Render supports. The first format is the default. If no formats
+// are given, DefaultFormats are used.
+func (o *Output) SetFormats(formats ...string) {
+	o.formats = formats
+}
+
+// Render writes the given value to the output using the current format. The table and CSV formats
+// render structs as a row, with a column for each exported field, slices as multiple rows, and maps
+// as rows of keys and values. Machine-readable formats are written as-is, without markup being
+// rendered.
+func (o *Output) Render(v interface{}) error {
+	name, arg := splitFormat(o.Format())
+
+	switch name {
+	case FormatTable:
+		headers, rows := tabulate(v)
+
+		// Values are data, not markup, so they're escaped before the table is rendered.
+		for i, header := range headers {
+			headers[i] = EscapeMarkup(strings.ToUpper(header))
+		}
+
+		for _, row := range rows {
+			for i, cell := range row {
+				row[i] = EscapeMarkup(cell)
+			}
+		}
+
+		table := NewTable(o)
+		table.BorderStyle = TableBorderNone
+		table.SetHeaders(headers...)
+		table.AddRows(rows...)
+
+		return o.writeRaw(o.markup(o.Writer, table.String()))
+	case FormatText:
+		text := fmt.Sprint(v)
+		if !strings.HasSuffix(text, "\n") {
+			text += "\n"
+		}
+
+		return o.writeRaw(text)
+	case FormatJSON:
+		bs, err := json.MarshalIndent(v, "", "  ")
+		if err != nil {
+			return err
+		}
+
+		return o.writeRaw(string(bs) + "\n")
+	case FormatYAML:
+		bs, err := yaml.Marshal(v)
+		if err != nil {
+			return err
+		}
+
+		return o.writeRaw(string(bs))
+	case FormatCSV:
+		headers, rows := tabulate(v)
+
+		buf := bytes.Buffer{}
+		writer := csv.NewWriter(&buf)
+
+		if len(headers) > 0 {
+			writer.Write(headers)
+		}
+
+		writer.WriteAll(rows)
+
+		if err := writer.Error(); err != nil {
+			return err
+		}
+
+		return o.writeRaw(buf.String())
+	case FormatTemplate:
+		tmpl, err := template.New("output").Parse(arg)
+		if err != nil {
+			return err
+		}
+
+		buf := bytes.Buffer{}
+		if err := tmpl.Execute(&buf, v); err != nil {
+			return err
+		}
+
+		if !bytes.HasSuffix(buf.Bytes(), []byte("\n")) {
+			buf.WriteString("\n")
+		}
+
+		return o.writeRaw(buf.String())
+	}
+
+	return fmt.Errorf("unsupported output format '%s'", name)
+}
+
+// supportedFormats gets the formats supported by Render.
+func (o *Output) supportedFormats() []string {
+	if len(o.formats) > 0 {
+		return o.formats
+	}
+
+	return DefaultFormats
+}
+
+// writeRaw writes the given text to the output, without rendering markup. Rendered output is
+// always written, even if the verbosity is quiet.
+func (o *Output) writeRaw(text string) error {
+	_, err := o.writeText(text)
+
+	return err
+}
+
+// splitFormat splits a format into it's name and argument (e.g. "template={{.Name}}").
+func splitFormat(format string) (string, string) {
+	split := strings.SplitN(format, "=", 2)
+	if len(split) == 2 {
+		return split[0], split[1]
+	}
+
+	return split[0], ""
+}
+
+// tabulate converts the given value into headers and rows, for rendering as a table or CSV.
+func tabulate(v interface{}) ([]string, [][]string) {
+	rv := reflect.ValueOf(v)
+	for rv.Kind() == reflect.Ptr || rv.Kind() == reflect.Interface {
+		if rv.IsNil() {
+			return nil, nil
+		}
+
+		rv = rv.Elem()
+	}
+
+	// Untyped nils (including nil elements of a slice) have nothing to show.
+	if !rv.IsValid() {
+		return nil, nil
+	}
+
+	switch rv.Kind() {
+	case reflect.Slice, reflect.Array:
+		var headers []string
+		var rows [][]string
+
+		for i := 0; i < rv.Len(); i++ {
+			elemHeaders, elemRows := tabulate(rv.Index(i).Interface())
+			if headers == nil {
+				headers = elemHeaders
+			}
+
+			rows = append(rows, elemRows...)
+		}
+
+		return headers, rows
+	case reflect.Struct:
+		var headers []string
+		var row []string
+
+		for i := 0; i < rv.NumField(); i++ {
+			field := rv.Type().Field(i)
+			if field.PkgPath != "" || field.Tag.Get("json") == "-" {
+				continue
+			}
+
+			headers = append(headers, field.Name)
+			row = append(row, fmt.Sprint(rv.Field(i).Interface()))
+		}
+
+		return headers, [][]string{row}
+	case reflect.Map:
+		var rows [][]string
+
+		for _, key := range rv.MapKeys() {
+			rows = append(rows, []string{fmt.Sprint(key.Interface()), fmt.Sprint(rv.MapIndex(key).Interface())})
+		}
+
+		sort.Slice(rows, func(i, j int) bool {
+			return rows[i][0] < rows[j][0]
+		})
+
+		return []string{"Key", "Value"}, rows
+	}
+
+	return nil, [][]string{{fmt.Sprint(rv.Interface())}}
+}
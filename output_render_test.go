@@ -0,0 +1,201 @@
+package console_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/seeruk/go-console"
+	"github.com/stretchr/testify/assert"
+)
+
+type testRenderItem struct {
+	Name   string `json:"name" yaml:"name"`
+	Count  int    `json:"count" yaml:"count"`
+	Secret string `json:"-" yaml:"-"`
+}
+
+func TestOutputRender(t *testing.T) {
+	items := []testRenderItem{
+		{Name: "foo", Count: 1, Secret: "s"},
+		{Name: "bar", Count: 22, Secret: "s"},
+	}
+
+	render := func(format string, v interface{}) (string, error) {
+		buffer := bytes.Buffer{}
+
+		output := console.NewOutput(&buffer)
+		if format != "" {
+			if err := output.SetFormat(format); err != nil {
+				return "", err
+			}
+		}
+
+		err := output.Render(v)
+
+		return buffer.String(), err
+	}
+
+	t.Run("should render a table by default", func(t *testing.T) {
+		result, err := render("", items)
+
+		expected := strings.Join([]string{
+			"NAME  COUNT",
+			"foo   1",
+			"bar   22",
+			"",
+		}, "\n")
+
+		assert.NoError(t, err)
+		assert.Equal(t, expected, result)
+	})
+
+	t.Run("should render maps as keys and values", func(t *testing.T) {
+		result, err := render(console.FormatCSV, map[string]int{"b": 2, "a": 1})
+
+		assert.NoError(t, err)
+		assert.Equal(t, "Key,Value\na,1\nb,2\n", result)
+	})
+
+	t.Run("should render JSON", func(t *testing.T) {
+		result, err := render(console.FormatJSON, items[0])
+
+		assert.NoError(t, err)
+		assert.Equal(t, "{\n  \"name\": \"foo\",\n  \"count\": 1\n}\n", result)
+	})
+
+	t.Run("should render YAML", func(t *testing.T) {
+		result, err := render(console.FormatYAML, items[0])
+
+		assert.NoError(t, err)
+		assert.Equal(t, "name: foo\ncount: 1\n", result)
+	})
+
+	t.Run("should render CSV", func(t *testing.T) {
+		result, err := render(console.FormatCSV, items)
+
+		assert.NoError(t, err)
+		assert.Equal(t, "Name,Count\nfoo,1\nbar,22\n", result)
+	})
+
+	t.Run("should render Go templates", func(t *testing.T) {
+		result, err := render("template={{range .}}{{.Name}} {{end}}", items)
+
+		assert.NoError(t, err)
+		assert.Equal(t, "foo bar \n", result)
+	})
+
+	t.Run("should render text", func(t *testing.T) {
+		result, err := render(console.FormatText, "hello")
+
+		assert.NoError(t, err)
+		assert.Equal(t, "hello\n", result)
+	})
+
+	t.Run("should not render markup in machine-readable formats", func(t *testing.T) {
+		result, err := render(console.FormatJSON, "<info>hello</info>")
+
+		assert.NoError(t, err)
+		assert.Equal(t, "\"\\u003cinfo\\u003ehello\\u003c/info\\u003e\"\n", result)
+	})
+
+	t.Run("should not render markup in values", func(t *testing.T) {
+		result, err := render(console.FormatText, "<info>hello</info>")
+
+		assert.NoError(t, err)
+		assert.Equal(t, "<info>hello</info>\n", result)
+
+		result, err = render("", []testRenderItem{{Name: "<info>foo</info>"}})
+
+		assert.NoError(t, err)
+		assert.Contains(t, result, "<info>foo</info>")
+	})
+
+	t.Run("should render nil values as tables without panicking", func(t *testing.T) {
+		for _, format := range []string{"", console.FormatCSV} {
+			_, err := render(format, nil)
+			assert.NoError(t, err)
+
+			result, err := render(format, []interface{}{nil, 1})
+			assert.NoError(t, err)
+			assert.Contains(t, result, "1")
+		}
+	})
+
+	t.Run("should only allow supported formats", func(t *testing.T) {
+		output := console.NewOutput(&bytes.Buffer{})
+		output.SetFormats(console.FormatJSON, console.FormatYAML)
+
+		assert.Equal(t, console.FormatJSON, output.Format())
+		assert.NoError(t, output.SetFormat(console.FormatYAML))
+		assert.Error(t, output.SetFormat(console.FormatCSV))
+	})
+}
+
+func TestApplicationOutputFormat(t *testing.T) {
+	run := func(formats []string, args ...string) (string, string, int) {
+		writer := bytes.Buffer{}
+		errWriter := bytes.Buffer{}
+
+		application := console.NewApplication("seeruk/go-console", "1.2.3+testing")
+		application.Writer = &writer
+		application.ErrWriter = &errWriter
+		application.EnableOutputFormat = true
+		application.AddCommand(&console.Command{
+			Name:          "test",
+			OutputFormats: formats,
+			Execute: func(input *console.Input, output *console.Output) error {
+				return output.Render(testRenderItem{Name: "foo", Count: 1})
+			},
+		})
+
+		code := application.Run(append([]string{"test"}, args...), []string{})
+
+		return writer.String(), errWriter.String(), code
+	}
+
+	t.Run("should use the format given by the output option", func(t *testing.T) {
+		result, _, code := run(nil, "--output", "csv")
+
+		assert.Equal(t, 0, code)
+		assert.Equal(t, "Name,Count\nfoo,1\n", result)
+
+		result, _, code = run(nil, "-o", "csv")
+
+		assert.Equal(t, 0, code)
+		assert.Equal(t, "Name,Count\nfoo,1\n", result)
+
+		result, _, code = run(nil, "--output=template={{.Name}}")
+
+		assert.Equal(t, 0, code)
+		assert.Equal(t, "foo\n", result)
+	})
+
+	t.Run("should use the command's default format", func(t *testing.T) {
+		result, _, code := run([]string{console.FormatYAML})
+
+		assert.Equal(t, 0, code)
+		assert.Equal(t, "name: foo\ncount: 1\n", result)
+	})
+
+	t.Run("should fail if the command does not support the format", func(t *testing.T) {
+		_, errResult, code := run([]string{console.FormatJSON}, "--output", "csv")
+
+		assert.Equal(t, 101, code)
+		assert.Contains(t, errResult, "unsupported output format 'csv'")
+	})
+
+	t.Run("should be used by the version command", func(t *testing.T) {
+		writer := bytes.Buffer{}
+
+		application := console.NewApplication("seeruk/go-console", "1.2.3+testing")
+		application.Writer = &writer
+		application.EnableOutputFormat = true
+		application.EnableVersion = true
+
+		code := application.Run([]string{"version", "-o", "yaml"}, []string{})
+
+		assert.Equal(t, 0, code)
+		assert.Contains(t, writer.String(), "version: 1.2.3+testing")
+	})
+}
@@ -111,4 +111,98 @@ func TestOutput(t *testing.T) {
 			assert.Equal(t, expected, buffer.String())
 		})
 	})
+
+	t.Run("Printf()", func(t *testing.T) {
+		t.Run("should not render markup", func(t *testing.T) {
+			buffer := bytes.Buffer{}
+			output := console.NewOutput(&buffer)
+
+			message := "<info>Hello</info>, \\<World>!"
+
+			nbytes, err := output.Printf("%s", message)
+
+			assert.NoError(t, err)
+			assert.Equal(t, len(message), nbytes)
+			assert.Equal(t, message, buffer.String())
+		})
+	})
+
+	t.Run("Styledf()", func(t *testing.T) {
+		t.Run("should render markup", func(t *testing.T) {
+			buffer := bytes.Buffer{}
+			output := console.NewOutput(&buffer)
+
+			nbytes, err := output.Styledf("<info>Hello</info>, %s!", "\\<World>")
+
+			assert.NoError(t, err)
+			assert.Equal(t, 15, nbytes)
+			assert.Equal(t, "Hello, <World>!", buffer.String())
+		})
+	})
+
+	t.Run("Errorf()", func(t *testing.T) {
+		t.Run("should print to the error writer", func(t *testing.T) {
+			buffer := bytes.Buffer{}
+			errBuffer := bytes.Buffer{}
+			output := console.NewOutput(&buffer)
+			output.ErrWriter = &errBuffer
+
+			nbytes, err := output.Errorf("Hello, %s!", "World")
+
+			assert.NoError(t, err)
+			assert.Equal(t, 13, nbytes)
+			assert.Equal(t, "", buffer.String())
+			assert.Equal(t, "Hello, World!", errBuffer.String())
+		})
+
+		t.Run("should print to the writer if no error writer is set", func(t *testing.T) {
+			buffer := bytes.Buffer{}
+			output := console.NewOutput(&buffer)
+
+			output.Errorf("Hello, %s!", "World")
+
+			assert.Equal(t, "Hello, World!", buffer.String())
+		})
+	})
+
+	t.Run("Errorln()", func(t *testing.T) {
+		t.Run("should print to the error writer", func(t *testing.T) {
+			buffer := bytes.Buffer{}
+			errBuffer := bytes.Buffer{}
+			output := console.NewOutput(&buffer)
+			output.ErrWriter = &errBuffer
+
+			nbytes, err := output.Errorln("Hello, World!")
+
+			assert.NoError(t, err)
+			assert.Equal(t, 14, nbytes)
+			assert.Equal(t, "", buffer.String())
+			assert.Equal(t, "Hello, World!\n", errBuffer.String())
+		})
+	})
+
+	t.Run("Width()", func(t *testing.T) {
+		t.Run("should use the default width if it can't be detected", func(t *testing.T) {
+			output := console.NewOutput(&bytes.Buffer{})
+
+			assert.Equal(t, console.DefaultWidth, output.Width())
+		})
+
+		t.Run("should use the width that was set", func(t *testing.T) {
+			output := console.NewOutput(&bytes.Buffer{})
+			output.SetWidth(100)
+
+			assert.Equal(t, 100, output.Width())
+		})
+
+		t.Run("should keep the width between the minimum and maximum", func(t *testing.T) {
+			output := console.NewOutput(&bytes.Buffer{})
+
+			output.SetWidth(10)
+			assert.Equal(t, console.MinWidth, output.Width())
+
+			output.SetWidth(1000)
+			assert.Equal(t, console.MaxWidth, output.Width())
+		})
+	})
 }
@@ -0,0 +1,98 @@
+package console
+
+import (
+	"fmt"
+)
+
+// Verbosity levels.
+const (
+	VerbosityQuiet Verbosity = iota - 1
+	VerbosityNormal
+	VerbosityVerbose
+	VerbosityVeryVerbose
+	VerbosityDebug
+)
+
+// Verbosity represents how much output should be written. Regular output (e.g. Printf) is written
+// unless the verbosity is quiet, and the level-gated methods (e.g. Verbosef, Debugf) only write if
+// the Output's verbosity is at least their level. Level-gated output is diagnostic, so it is written
+// to the error writer. Errors (e.g. Errorf), and structured output (see Render) are always written.
+type Verbosity int
+
+// Verbosity gets the current verbosity level.
+func (o *Output) Verbosity() Verbosity {
+	return o.verbosity
+}
+
+// SetVerbosity sets the verbosity level. By default, the verbosity is VerbosityNormal.
+func (o *Output) SetVerbosity(verbosity Verbosity) {
+	o.verbosity = verbosity
+}
+
+// IsQuiet checks to see if the verbosity level is quiet.
+func (o *Output) IsQuiet() bool {
+	return o.verbosity <= VerbosityQuiet
+}
+
+// IsVerbose checks to see if the verbosity level is at least verbose.
+func (o *Output) IsVerbose() bool {
+	return o.verbosity >= VerbosityVerbose
+}
+
+// IsVeryVerbose checks to see if the verbosity level is at least very verbose.
+func (o *Output) IsVeryVerbose() bool {
+	return o.verbosity >= VerbosityVeryVerbose
+}
+
+// IsDebug checks to see if the verbosity level is debug.
+func (o *Output) IsDebug() bool {
+	return o.verbosity >= VerbosityDebug
+}
+
+// Verbosef is like Errorf, but only writes if the verbosity level is at least verbose.
+func (o *Output) Verbosef(format string, a ...interface{}) (int, error) {
+	return o.levelf(VerbosityVerbose, format, a...)
+}
+
+// Verboseln is like Errorln, but only writes if the verbosity level is at least verbose.
+func (o *Output) Verboseln(a ...interface{}) (int, error) {
+	return o.levelln(VerbosityVerbose, a...)
+}
+
+// VeryVerbosef is like Errorf, but only writes if the verbosity level is at least very verbose.
+func (o *Output) VeryVerbosef(format string, a ...interface{}) (int, error) {
+	return o.levelf(VerbosityVeryVerbose, format, a...)
+}
+
+// VeryVerboseln is like Errorln, but only writes if the verbosity level is at least very verbose.
+func (o *Output) VeryVerboseln(a ...interface{}) (int, error) {
+	return o.levelln(VerbosityVeryVerbose, a...)
+}
+
+// Debugf is like Errorf, but only writes if the verbosity level is debug.
+func (o *Output) Debugf(format string, a ...interface{}) (int, error) {
+	return o.levelf(VerbosityDebug, format, a...)
+}
+
+// Debugln is like Errorln, but only writes if the verbosity level is debug.
+func (o *Output) Debugln(a ...interface{}) (int, error) {
+	return o.levelln(VerbosityDebug, a...)
+}
+
+// levelf writes formatted output to the error writer if the verbosity is at least the given level.
+func (o *Output) levelf(level Verbosity, format string, a ...interface{}) (int, error) {
+	if o.verbosity < level {
+		return 0, nil
+	}
+
+	return o.write(o.ErrWriter, fmt.Sprintf(format, a...))
+}
+
+// levelln writes output to the error writer if the verbosity is at least the given level.
+func (o *Output) levelln(level Verbosity, a ...interface{}) (int, error) {
+	if o.verbosity < level {
+		return 0, nil
+	}
+
+	return o.write(o.ErrWriter, fmt.Sprintln(a...))
+}
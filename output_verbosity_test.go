@@ -0,0 +1,153 @@
+package console_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/seeruk/go-console"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOutputVerbosity(t *testing.T) {
+	t.Run("should default to normal verbosity", func(t *testing.T) {
+		output := console.NewOutput(&bytes.Buffer{})
+
+		assert.Equal(t, console.VerbosityNormal, output.Verbosity())
+		assert.False(t, output.IsQuiet())
+		assert.False(t, output.IsVerbose())
+	})
+
+	t.Run("should only write level-gated output at or above the level", func(t *testing.T) {
+		tests := []struct {
+			verbosity console.Verbosity
+			expected  string
+		}{
+			{console.VerbosityQuiet, ""},
+			{console.VerbosityNormal, ""},
+			{console.VerbosityVerbose, "v\n"},
+			{console.VerbosityVeryVerbose, "v\nvv\n"},
+			{console.VerbosityDebug, "v\nvv\ndebug\n"},
+		}
+
+		for _, test := range tests {
+			buffer := bytes.Buffer{}
+			output := console.NewOutput(&buffer)
+			output.SetVerbosity(test.verbosity)
+
+			output.Verbosef("v\n")
+			output.VeryVerboseln("vv")
+			output.Debugf("%s\n", "debug")
+
+			assert.Equal(t, test.expected, buffer.String())
+		}
+	})
+
+	t.Run("should write level-gated output to the error writer", func(t *testing.T) {
+		buffer := bytes.Buffer{}
+		errBuffer := bytes.Buffer{}
+		output := console.NewOutput(&buffer)
+		output.ErrWriter = &errBuffer
+		output.SetVerbosity(console.VerbosityDebug)
+
+		output.Debugln("debug")
+
+		assert.Equal(t, "", buffer.String())
+		assert.Equal(t, "debug\n", errBuffer.String())
+	})
+
+	t.Run("should only write errors in quiet mode", func(t *testing.T) {
+		buffer := bytes.Buffer{}
+		errBuffer := bytes.Buffer{}
+		output := console.NewOutput(&buffer)
+		output.ErrWriter = &errBuffer
+		output.SetVerbosity(console.VerbosityQuiet)
+
+		output.Print("print")
+		output.Printf("%s\n", "printf")
+		output.Println("println")
+		output.Errorln("error")
+
+		assert.Equal(t, "", buffer.String())
+		assert.Equal(t, "error\n", errBuffer.String())
+	})
+}
+
+func TestApplicationVerbosity(t *testing.T) {
+	runWithVerbosity := func(args ...string) (console.Verbosity, string) {
+		var verbosity console.Verbosity
+
+		errWriter := bytes.Buffer{}
+
+		application := console.NewApplication("seeruk/go-console", "1.2.3+testing")
+		application.Writer = &bytes.Buffer{}
+		application.ErrWriter = &errWriter
+		application.EnableVerbosity = true
+		application.AddCommand(&console.Command{
+			Name: "test",
+			Execute: func(input *console.Input, output *console.Output) error {
+				verbosity = output.Verbosity()
+				return nil
+			},
+		})
+
+		application.Run(append([]string{"test"}, args...), []string{})
+
+		return verbosity, errWriter.String()
+	}
+
+	t.Run("should set the verbosity from global options", func(t *testing.T) {
+		tests := map[string]console.Verbosity{
+			"":          console.VerbosityNormal,
+			"-v":        console.VerbosityVerbose,
+			"--verbose": console.VerbosityVerbose,
+			"-vv":       console.VerbosityVeryVerbose,
+			"-vvv":      console.VerbosityDebug,
+			"-vvvv":     console.VerbosityDebug,
+			"-q":        console.VerbosityQuiet,
+			"--quiet":   console.VerbosityQuiet,
+		}
+
+		for arg, expected := range tests {
+			var args []string
+			if arg != "" {
+				args = append(args, arg)
+			}
+
+			verbosity, _ := runWithVerbosity(args...)
+
+			assert.Equal(t, expected, verbosity, "Unexpected verbosity for '%s'", arg)
+		}
+	})
+
+	t.Run("should let quiet override verbose", func(t *testing.T) {
+		verbosity, _ := runWithVerbosity("-v", "-q", "-v")
+
+		assert.Equal(t, console.VerbosityQuiet, verbosity)
+	})
+
+	t.Run("should write framework debug output in debug mode", func(t *testing.T) {
+		_, errOutput := runWithVerbosity("-vvv")
+
+		assert.Contains(t, errOutput, "running")
+	})
+
+	t.Run("should not show the help hint on errors in quiet mode", func(t *testing.T) {
+		errWriter := bytes.Buffer{}
+
+		application := console.NewApplication("seeruk/go-console", "1.2.3+testing")
+		application.ErrWriter = &errWriter
+		application.EnableVerbosity = true
+		application.AddCommand(&console.Command{
+			Name: "test",
+			Execute: func(input *console.Input, output *console.Output) error {
+				return assert.AnError
+			},
+		})
+
+		code := application.Run([]string{"test", "-q"}, []string{})
+
+		assert.Equal(t, 1, code)
+		assert.Contains(t, errWriter.String(), assert.AnError.Error())
+		assert.NotContains(t, errWriter.String(), "Try '")
+	})
+}
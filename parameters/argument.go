@@ -8,6 +8,15 @@ type Argument struct {
 	Description string
 	// The value that this argument references.
 	Value Value
+	// The default value of this argument, as it's shown in help (see DefaultValue). This is
+	// recorded when the argument is defined, so it isn't changed by mapping input to Value.
+	Default string
 	// Is this argument required?
 	Required bool
+	// Is the value of this argument secret (i.e. it should not be shown when entered)?
+	Secret bool
+	// Function to suggest values for this argument, when completing input in a shell.
+	Complete CompleteFunc
+	// How a shell should complete the value of this argument.
+	CompleteDirective CompletionDirective
 }
@@ -2,17 +2,39 @@ package parameters
 
 import (
 	"fmt"
-	"sort"
 	"strings"
 
 	"github.com/seeruk/go-wordwrap"
 )
 
+// ArgumentsFormat configures how arguments are described by DescribeArgumentsFormat.
+type ArgumentsFormat struct {
+	// The width to wrap descriptions to. If 0, DefaultWidth is used.
+	Width int
+	// Leaves out the "ARGUMENTS:" title (e.g. so that a help renderer can show it's own).
+	NoTitle bool
+}
+
 // DescribeArguments describes an array of Arguments, formatting them in a helpful way.
 func DescribeArguments(arguments []Argument) string {
-	desc := "ARGUMENTS:\n"
+	return DescribeArgumentsFormat(arguments, ArgumentsFormat{})
+}
+
+// DescribeArgumentsFormat describes an array of Arguments like DescribeArguments does, using the
+// given format.
+func DescribeArgumentsFormat(arguments []Argument, format ArgumentsFormat) string {
+	width := format.Width
+	if width == 0 {
+		width = DefaultWidth
+	}
+
+	var desc string
+	if !format.NoTitle {
+		desc = "ARGUMENTS:\n"
+	}
 
-	// Create array and map for specific output ordering
+	// Create array and map for specific output ordering. Arguments are positional, so they're
+	// always shown in the order they were defined in.
 	argDescKeys := []string{}
 	argDescMap := make(map[string]string)
 
@@ -22,25 +44,22 @@ func DescribeArguments(arguments []Argument) string {
 		argDescMap[arg.Name] = arg.Description
 	}
 
-	// Sort option names, so they are output in alphabetical order.
-	sort.Sort(argumentNameSort(argDescKeys))
-
 	// Find maximum option names width for spacing.
-	var width int
+	var column int
 	for _, name := range argDescKeys {
 		nameLen := len(name)
 
-		if nameLen+2 > width {
-			width = nameLen + 2
+		if nameLen+2 > column {
+			column = nameLen + 2
 		}
 	}
 
 	for _, names := range argDescKeys {
 		// Get space for the right-side of the command name.
-		spacing := width - len(names)
+		spacing := column - len(names)
 
 		// Wrap the description onto new lines if necessary.
-		wrapper := wordwrap.Wrapper(78-width, true)
+		wrapper := wordwrap.Wrapper(WrapWidth(width, column), true)
 		wrapped := wrapper(argDescMap[names])
 
 		// Indent and prefix to product the result.
@@ -51,18 +70,3 @@ func DescribeArguments(arguments []Argument) string {
 
 	return desc
 }
-
-// argumentNameSort allows argument name sorting (trim leading brackets, and alphabetically sort).
-type argumentNameSort []string
-
-func (a argumentNameSort) Len() int {
-	return len(a)
-}
-
-func (a argumentNameSort) Less(i, j int) bool {
-	return a[i] < a[j]
-}
-
-func (a argumentNameSort) Swap(i, j int) {
-	a[i], a[j] = a[j], a[i]
-}
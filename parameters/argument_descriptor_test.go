@@ -39,7 +39,7 @@ func TestDescribeArguments(t *testing.T) {
 		assert.True(t, strings.Contains(result, "TEST_ARG2"), "Expected argument name in result.")
 	})
 
-	t.Run("should sort arguments into alphabetical order", func(t *testing.T) {
+	t.Run("should show arguments in positional order", func(t *testing.T) {
 		result := parameters.DescribeArguments([]parameters.Argument{
 			{
 				Name: "FOO",
@@ -52,6 +52,25 @@ func TestDescribeArguments(t *testing.T) {
 		fooIdx := strings.Index(result, "FOO")
 		barIdx := strings.Index(result, "BAR")
 
-		assert.True(t, fooIdx > barIdx, "Expected FOO to come after BAR.")
+		assert.True(t, fooIdx < barIdx, "Expected FOO to come before BAR.")
+	})
+}
+
+func TestDescribeArgumentsFormat(t *testing.T) {
+	t.Run("should leave out the title if configured to", func(t *testing.T) {
+		result := parameters.DescribeArgumentsFormat([]parameters.Argument{
+			{Name: "STEPS", Description: "The number of steps."},
+		}, parameters.ArgumentsFormat{Width: 80, NoTitle: true})
+
+		assert.Equal(t, "  STEPS  The number of steps.\n", result)
+	})
+	t.Run("should wrap descriptions to the given width", func(t *testing.T) {
+		result := parameters.DescribeArgumentsFormat([]parameters.Argument{
+			{Name: "STEPS", Description: strings.Repeat("word ", 20)},
+		}, parameters.ArgumentsFormat{Width: 40})
+
+		for _, line := range strings.Split(strings.TrimSpace(result), "\n") {
+			assert.True(t, len(line) <= 40, "Expected line to fit in width.")
+		}
 	})
 }
@@ -0,0 +1,25 @@
+package parameters
+
+// Completion directives, which may be combined.
+const (
+	// CompleteDefault uses suggestions if there are any, otherwise it falls back to the shell's
+	// default completion (usually file names).
+	CompleteDefault CompletionDirective = 0
+	// CompleteNoSpace stops a space from being added after a completed value.
+	CompleteNoSpace CompletionDirective = 1 << (iota - 1)
+	// CompleteNoFiles stops the shell from falling back to completing file names.
+	CompleteNoFiles
+	// CompleteFiles completes file names, as well as any suggestions.
+	CompleteFiles
+	// CompleteDirectories completes directory names, as well as any suggestions.
+	CompleteDirectories
+)
+
+// CompletionDirective tells a shell how to complete the value of an argument or option.
+type CompletionDirective int
+
+// CompleteFunc suggests values for an argument or option, given the part of the value that has been
+// typed so far. Each suggestion may include a description, separated from the value by a tab (e.g.
+// "prod\tThe production database"). Suggestions that don't start with the prefix are ignored, so a
+// CompleteFunc may return every possible value.
+type CompleteFunc func(prefix string) []string
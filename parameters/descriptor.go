@@ -0,0 +1,18 @@
+package parameters
+
+// DefaultWidth is the width that descriptions are wrapped to when no width is given.
+const DefaultWidth = 80
+
+// MinWrapWidth is the narrowest that a description will be wrapped to, no matter how little space
+// is left for it.
+const MinWrapWidth = 20
+
+// WrapWidth finds the width that descriptions should be wrapped to, given the width of the whole
+// line, and the width of the column that names are shown in (which is indented by 2 spaces).
+func WrapWidth(width int, column int) int {
+	if wrap := width - column - 2; wrap > MinWrapWidth {
+		return wrap
+	}
+
+	return MinWrapWidth
+}
@@ -17,12 +17,27 @@ type Option struct {
 	Names []string
 	// The description of this option.
 	Description string
+	// The name of the group this option is shown in, in help (e.g. "Connection options").
+	Group string
 	// The name of an environment variable to read an option value from.
 	EnvVar string
 	// The value that this option references.
 	Value Value
+	// The default value of this option, as it's shown in help (see DefaultValue). This is recorded
+	// when the option is defined, so it isn't changed by mapping input to Value.
+	Default string
 	// Does this option take a value? Is it optional, or required?
 	ValueMode OptionValueMode
 	// The name of the value (shown in contextual help).
 	ValueName string
+	// The values this option accepts, if it only accepts certain values.
+	Choices []string
+	// Is this option required?
+	Required bool
+	// Is the value of this option secret (i.e. it should not be shown when entered)?
+	Secret bool
+	// Function to suggest values for this option, when completing input in a shell.
+	Complete CompleteFunc
+	// How a shell should complete the value of this option.
+	CompleteDirective CompletionDirective
 }
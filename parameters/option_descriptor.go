@@ -8,97 +8,242 @@ import (
 	"github.com/seeruk/go-wordwrap"
 )
 
+// Annotations that can be added to option descriptions.
+const (
+	// AnnotateEnvVar shows the environment variable an option can be read from, e.g. `[env: NAME]`.
+	AnnotateEnvVar OptionAnnotation = 1 << iota
+	// AnnotateDefault shows an option's default value, e.g. `[default: localhost]`.
+	AnnotateDefault
+	// AnnotateChoices shows the values an option accepts, e.g. `[choices: auto, always, never]`.
+	AnnotateChoices
+	// AnnotateRequired shows that an option is required, with `(required)`.
+	AnnotateRequired
+	// AnnotateNone shows no annotations.
+	AnnotateNone
+
+	// DefaultOptionAnnotations are the annotations shown when none are specified.
+	DefaultOptionAnnotations = AnnotateEnvVar | AnnotateDefault | AnnotateChoices | AnnotateRequired
+)
+
+// OptionAnnotation represents extra information that can be added to an option's description
+// in help. Annotations can be combined (e.g. `AnnotateEnvVar | AnnotateRequired`).
+type OptionAnnotation int
+
+// Orders that options can be shown in.
+const (
+	// OptionOrderAlphabetical shows options in alphabetical order of their names.
+	OptionOrderAlphabetical OptionOrder = iota
+	// OptionOrderDeclaration shows options in the order they were defined in.
+	OptionOrderDeclaration
+)
+
+// OptionOrder represents the order that options are shown in, within their groups.
+type OptionOrder int
+
+// OptionsFormat configures how options are described by DescribeOptionsFormat.
+type OptionsFormat struct {
+	// The width to wrap descriptions to. If 0, DefaultWidth is used.
+	Width int
+	// The annotations to add to descriptions. If 0, DefaultOptionAnnotations are added.
+	Annotations OptionAnnotation
+	// The order options are shown in, within their groups.
+	Order OptionOrder
+	// Leaves out the "OPTIONS:" title (e.g. so that a help renderer can show it's own).
+	NoTitle bool
+}
+
 // DescribeOptions describes an array of Options, formatting them in a helpful way.
 func DescribeOptions(options []Option) string {
-	desc := "OPTIONS:\n"
+	return DescribeOptionsFormat(options, OptionsFormat{})
+}
 
-	// Create array and map for specific output ordering
-	optDescKeys := []string{}
-	optDescMap := make(map[string]string)
+// DescribeOptionsFormat describes an array of Options like DescribeOptions does, using the given
+// format. Options are grouped and ordered as GroupOptions does, with a heading for each group (e.g.
+// "Connection options:").
+func DescribeOptionsFormat(options []Option, format OptionsFormat) string {
+	var desc string
+	if !format.NoTitle {
+		desc = "OPTIONS:\n"
+	}
 
-	// Generate the list of names, so that output can be formatted correctly, and in the correct
-	// order (i.e. alphabetical), and with sorted names for each option individually.
-	for _, opt := range options {
-		var names []string
-		for _, name := range opt.Names {
-			if len(name) > 1 {
-				name = "--" + name
-			} else {
-				name = "-" + name
+	width := format.Width
+	if width == 0 {
+		width = DefaultWidth
+	}
+
+	groups := GroupOptions(options, format.Order)
+
+	// Find maximum option names width for spacing.
+	var column int
+	for _, group := range groups {
+		for _, opt := range group.Options {
+			namesLen := len(DescribeOptionKey(opt, nil))
+
+			if namesLen+2 > column {
+				column = namesLen + 2
 			}
+		}
+	}
 
-			names = append(names, name)
+	for i, group := range groups {
+		if i > 0 {
+			desc += "\n"
 		}
 
-		// Sort the names so that short names appear first in the output.
-		sort.Sort(stringLengthSort(names))
+		if group.Name != "" {
+			desc += fmt.Sprintf("  %s:\n", group.Name)
+		}
 
-		// Join the names into on comma-separated string.
-		key := strings.Join(names, ", ")
+		for _, opt := range group.Options {
+			key := DescribeOptionKey(opt, nil)
 
-		// Describe option value
-		if opt.ValueMode == OptionValueOptional {
-			key += "["
-		}
+			// Get space for the right-side of the command name.
+			spacing := column - len(key)
+
+			// Wrap the description onto new lines if necessary.
+			wrapper := wordwrap.Wrapper(WrapWidth(width, column), true)
+			wrapped := wrapper(annotateOption(opt, format.Annotations))
+
+			// Indent and prefix to product the result.
+			prefix := fmt.Sprintf("  %s%s", key, strings.Repeat(" ", spacing))
 
-		if opt.ValueMode == OptionValueOptional || opt.ValueMode == OptionValueRequired {
-			key += "=" + opt.ValueName
+			desc += wordwrap.Indent(wrapped, prefix, false) + "\n"
 		}
+	}
+
+	return desc
+}
+
+// OptionGroup is a group of options, as they are shown in help.
+type OptionGroup struct {
+	// The name of the group. Empty for options that aren't in a group.
+	Name string
+	// The options in the group, in the order they should be shown in.
+	Options []Option
+}
+
+// GroupOptions groups options by their Group, in the given order. Options that aren't in a group
+// come first, followed by each group in the order they are first used. Groups without options are
+// left out, so the result is empty if there are no options.
+func GroupOptions(options []Option, order OptionOrder) []OptionGroup {
+	sorted := append([]Option{}, options...)
 
-		if opt.ValueMode == OptionValueOptional {
-			key += "]"
+	if order == OptionOrderAlphabetical {
+		// Sort option names, so they are output in alphabetical order.
+		sort.SliceStable(sorted, func(i, j int) bool {
+			return strings.TrimLeft(DescribeOptionKey(sorted[i], nil), "-") <
+				strings.TrimLeft(DescribeOptionKey(sorted[j], nil), "-")
+		})
+	}
+
+	groups := []OptionGroup{{}}
+	indexes := map[string]int{"": 0}
+
+	// Walk the options in declaration order so that groups are in the order they're first used.
+	for _, opt := range options {
+		if _, ok := indexes[opt.Group]; !ok {
+			indexes[opt.Group] = len(groups)
+			groups = append(groups, OptionGroup{Name: opt.Group})
 		}
+	}
 
-		optDescKeys = append(optDescKeys, key)
-		optDescMap[key] = opt.Description
+	for _, opt := range sorted {
+		index := indexes[opt.Group]
+		groups[index].Options = append(groups[index].Options, opt)
 	}
 
-	// Sort option names, so they are output in alphabetical order.
-	sort.Sort(optionNameSort(optDescKeys))
+	if len(groups[0].Options) == 0 {
+		groups = groups[1:]
+	}
 
-	// Find maximum option names width for spacing.
-	var width int
-	for _, names := range optDescKeys {
-		namesLen := len(names)
+	return groups
+}
+
+// Styles of the parts of an option key.
+const (
+	// OptionKeyPlain is punctuation, like the `, ` between names, and the `=` before the value.
+	OptionKeyPlain OptionKeyStyle = iota
+	// OptionKeyName is an option's name, like `--dsn`.
+	OptionKeyName
+	// OptionKeyValue is the name of an option's value, like `DSN`.
+	OptionKeyValue
+)
+
+// OptionKeyStyle represents how a part of an option key should be shown, e.g. in a man page.
+type OptionKeyStyle int
+
+// OptionKeyStyler formats a part of an option key in the given style.
+type OptionKeyStyler func(text string, style OptionKeyStyle) string
+
+// DescribeOptionKey describes an option's names and value, e.g. `-d, --dsn=DSN`. Short names are
+// shown first. Each part of the key is passed to the given styler, so that it can be formatted for
+// where it's shown; if the styler is nil, the key is plain text.
+func DescribeOptionKey(opt Option, styler OptionKeyStyler) string {
+	if styler == nil {
+		styler = func(text string, _ OptionKeyStyle) string {
+			return text
+		}
+	}
 
-		if namesLen+2 > width {
-			width = namesLen + 2
+	var names []string
+	for _, name := range opt.Names {
+		if len(name) > 1 {
+			name = "--" + name
+		} else {
+			name = "-" + name
 		}
+
+		names = append(names, name)
 	}
 
-	for _, names := range optDescKeys {
-		// Get space for the right-side of the command name.
-		spacing := width - len(names)
+	// Sort the names so that short names appear first in the output.
+	sort.Sort(stringLengthSort(names))
 
-		// Wrap the description onto new lines if necessary.
-		wrapper := wordwrap.Wrapper(78-width, true)
-		wrapped := wrapper(optDescMap[names])
+	var key string
+	for i, name := range names {
+		if i > 0 {
+			key += styler(", ", OptionKeyPlain)
+		}
 
-		// Indent and prefix to product the result.
-		prefix := fmt.Sprintf("  %s%s", names, strings.Repeat(" ", spacing))
+		key += styler(name, OptionKeyName)
+	}
 
-		desc += wordwrap.Indent(wrapped, prefix, false) + "\n"
+	switch opt.ValueMode {
+	case OptionValueRequired:
+		key += styler("=", OptionKeyPlain) + styler(opt.ValueName, OptionKeyValue)
+	case OptionValueOptional:
+		key += styler("[=", OptionKeyPlain) + styler(opt.ValueName, OptionKeyValue) + styler("]", OptionKeyPlain)
 	}
 
-	return desc
+	return key
 }
 
-// optionNameSort allows option name sorting (trim leading hyphens, and alphabetically sort).
-type optionNameSort []string
+// annotateOption adds the given annotations to the description of an option, if they apply to it.
+// Default values of secret options are never shown.
+func annotateOption(opt Option, annotations OptionAnnotation) string {
+	if annotations == 0 {
+		annotations = DefaultOptionAnnotations
+	}
 
-func (a optionNameSort) Len() int {
-	return len(a)
-}
+	desc := opt.Description
 
-func (a optionNameSort) Less(i, j int) bool {
-	l := strings.TrimLeft(a[i], "-")
-	r := strings.TrimLeft(a[j], "-")
+	if annotations&AnnotateRequired != 0 && opt.Required {
+		desc += " (required)"
+	}
 
-	return l < r
-}
+	if annotations&AnnotateEnvVar != 0 && opt.EnvVar != "" {
+		desc += fmt.Sprintf(" [env: %s]", opt.EnvVar)
+	}
 
-func (a optionNameSort) Swap(i, j int) {
-	a[i], a[j] = a[j], a[i]
+	if annotations&AnnotateDefault != 0 && opt.Default != "" && !opt.Secret {
+		desc += fmt.Sprintf(" [default: %s]", opt.Default)
+	}
+
+	if annotations&AnnotateChoices != 0 && len(opt.Choices) > 0 {
+		desc += fmt.Sprintf(" [choices: %s]", strings.Join(opt.Choices, ", "))
+	}
+
+	return strings.TrimSpace(desc)
 }
 
 // stringLengthSort allows string length sorting, first tries to sort by length, falls back to
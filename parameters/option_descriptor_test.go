@@ -128,4 +128,207 @@ func TestDescribeOptions(t *testing.T) {
 
 		assert.True(t, fIdx > bIdx, "Expected -f to come after -b.")
 	})
+
+	t.Run("should wrap descriptions to the given width", func(t *testing.T) {
+		result := parameters.DescribeOptionsFormat([]parameters.Option{
+			{
+				Names:       []string{"f", "foo"},
+				Description: strings.Repeat("word ", 20),
+			},
+		}, parameters.OptionsFormat{Width: 40})
+
+		lines := strings.Split(strings.TrimSpace(result), "\n")
+
+		assert.True(t, len(lines) > 2, "Expected description to be wrapped.")
+
+		for _, line := range lines {
+			assert.True(t, len(line) <= 40, "Expected line to fit in width.")
+		}
+	})
+	t.Run("should annotate descriptions", func(t *testing.T) {
+		host := "localhost"
+
+		result := parameters.DescribeOptionsFormat([]parameters.Option{
+			{
+				Names:       []string{"host"},
+				Description: "The host.",
+				EnvVar:      "APP_HOST",
+				Value:       parameters.NewStringValue(&host),
+				Default:     host,
+				ValueMode:   parameters.OptionValueRequired,
+				ValueName:   "HOST",
+				Choices:     []string{"localhost", "remote"},
+				Required:    true,
+			},
+		}, parameters.OptionsFormat{Width: 120})
+
+		assert.Contains(
+			t,
+			result,
+			"--host=HOST  The host. (required) [env: APP_HOST] [default: localhost] [choices: localhost, remote]",
+		)
+	})
+
+	t.Run("should not show the default value of secret options", func(t *testing.T) {
+		token := "hunter2"
+
+		result := parameters.DescribeOptions([]parameters.Option{
+			{
+				Names:       []string{"token"},
+				Description: "The token.",
+				Value:       parameters.NewStringValue(&token),
+				Default:     token,
+				Secret:      true,
+			},
+		})
+
+		assert.NotContains(t, result, "hunter2")
+	})
+
+	t.Run("should only add the given annotations", func(t *testing.T) {
+		host := "localhost"
+
+		options := []parameters.Option{
+			{
+				Names:       []string{"host"},
+				Description: "The host.",
+				EnvVar:      "APP_HOST",
+				Value:       parameters.NewStringValue(&host),
+				Required:    true,
+			},
+		}
+
+		result := parameters.DescribeOptionsFormat(options, parameters.OptionsFormat{
+			Width:       80,
+			Annotations: parameters.AnnotateEnvVar,
+		})
+		assert.Contains(t, result, "--host  The host. [env: APP_HOST]\n")
+
+		result = parameters.DescribeOptionsFormat(options, parameters.OptionsFormat{
+			Width:       80,
+			Annotations: parameters.AnnotateNone,
+		})
+		assert.Contains(t, result, "--host  The host.\n")
+	})
+	t.Run("should show options in declaration order if configured to", func(t *testing.T) {
+		options := []parameters.Option{
+			{Names: []string{"zone"}},
+			{Names: []string{"apple"}},
+		}
+
+		result := parameters.DescribeOptionsFormat(options, parameters.OptionsFormat{
+			Width: 80,
+			Order: parameters.OptionOrderDeclaration,
+		})
+
+		assert.True(t, strings.Index(result, "--zone") < strings.Index(result, "--apple"), "Expected --zone to come before --apple.")
+
+		result = parameters.DescribeOptionsFormat(options, parameters.OptionsFormat{Width: 80})
+
+		assert.True(t, strings.Index(result, "--zone") > strings.Index(result, "--apple"), "Expected --zone to come after --apple.")
+	})
+
+	t.Run("should show grouped options under group headings", func(t *testing.T) {
+		options := []parameters.Option{
+			{Names: []string{"host"}, Description: "The host.", Group: "Connection options"},
+			{Names: []string{"v", "verbose"}, Description: "Be verbose."},
+			{Names: []string{"port"}, Description: "The port.", Group: "Connection options"},
+		}
+
+		result := parameters.DescribeOptionsFormat(options, parameters.OptionsFormat{Width: 80})
+
+		expected := `OPTIONS:
+  -v, --verbose  Be verbose.
+
+  Connection options:
+  --host         The host.
+  --port         The port.
+`
+
+		assert.Equal(t, expected, result)
+	})
+}
+
+func TestDescribeOptionKey(t *testing.T) {
+	t.Run("should describe names and values as plain text without a styler", func(t *testing.T) {
+		opt := parameters.Option{
+			Names:     []string{"dsn", "d"},
+			ValueMode: parameters.OptionValueOptional,
+			ValueName: "DSN",
+		}
+
+		assert.Equal(t, "-d, --dsn[=DSN]", parameters.DescribeOptionKey(opt, nil))
+	})
+
+	t.Run("should pass each part to the styler", func(t *testing.T) {
+		opt := parameters.Option{
+			Names:     []string{"dsn", "d"},
+			ValueMode: parameters.OptionValueRequired,
+			ValueName: "DSN",
+		}
+
+		result := parameters.DescribeOptionKey(opt, func(text string, style parameters.OptionKeyStyle) string {
+			switch style {
+			case parameters.OptionKeyName:
+				return "<" + text + ">"
+			case parameters.OptionKeyValue:
+				return "{" + text + "}"
+			default:
+				return text
+			}
+		})
+
+		assert.Equal(t, "<-d>, <--dsn>={DSN}", result)
+	})
+}
+
+func TestGroupOptions(t *testing.T) {
+	options := []parameters.Option{
+		{Names: []string{"zone"}},
+		{Names: []string{"host"}, Group: "Connection options"},
+		{Names: []string{"apple"}},
+	}
+
+	names := func(groups []parameters.OptionGroup) [][]string {
+		var result [][]string
+		for _, group := range groups {
+			var groupNames []string
+			for _, opt := range group.Options {
+				groupNames = append(groupNames, opt.Names[0])
+			}
+
+			result = append(result, append([]string{group.Name}, groupNames...))
+		}
+
+		return result
+	}
+
+	t.Run("should put ungrouped options first, then each group", func(t *testing.T) {
+		groups := parameters.GroupOptions(options, parameters.OptionOrderAlphabetical)
+
+		assert.Equal(t, [][]string{{"", "apple", "zone"}, {"Connection options", "host"}}, names(groups))
+	})
+
+	t.Run("should keep declaration order if configured to", func(t *testing.T) {
+		groups := parameters.GroupOptions(options, parameters.OptionOrderDeclaration)
+
+		assert.Equal(t, [][]string{{"", "zone", "apple"}, {"Connection options", "host"}}, names(groups))
+	})
+
+	t.Run("should leave out groups without options", func(t *testing.T) {
+		groups := parameters.GroupOptions(options[1:2], parameters.OptionOrderAlphabetical)
+
+		assert.Equal(t, [][]string{{"Connection options", "host"}}, names(groups))
+		assert.Empty(t, parameters.GroupOptions(nil, parameters.OptionOrderAlphabetical))
+	})
+}
+
+func TestDescribeOptionsFormat(t *testing.T) {
+	t.Run("should leave out the title if configured to", func(t *testing.T) {
+		result := parameters.DescribeOptionsFormat([]parameters.Option{
+			{Names: []string{"v", "verbose"}, Description: "Be verbose."},
+		}, parameters.OptionsFormat{Width: 80, NoTitle: true})
+
+		assert.Equal(t, "  -v, --verbose  Be verbose.\n", result)
+	})
 }
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"net"
 	"net/url"
+	"reflect"
 	"strconv"
 	"time"
 )
@@ -23,6 +24,14 @@ type FlagValue interface {
 	FlagValue() string
 }
 
+// ValueValidator is implemented by Values that can check a string without setting it. Values that
+// aren't defined in this package should implement it if their input needs to be validated before
+// it's set (e.g. when it's prompted for), see ValidateValue.
+type ValueValidator interface {
+	Value
+	Validate(string) error
+}
+
 // BoolValue abstracts functionality for parsing input that should be represented as a boolean. The
 // BoolValue type also implements the FlagValue interface so that an alternative to the default
 // value can be used if no value is present.
@@ -225,3 +234,172 @@ func (v *URLValue) String() string {
 
 	return fmt.Sprintf("%v", u.String())
 }
+
+// NewValue creates a new Value for the given reference, picking the Value implementation based on
+// the type that is referenced. The reference must be a pointer. If the reference itself implements
+// Value, it is returned as-is.
+func NewValue(ref interface{}) (Value, error) {
+	if value, ok := ref.(Value); ok {
+		return value, nil
+	}
+
+	switch r := ref.(type) {
+	case *bool:
+		return NewBoolValue(r), nil
+	case *time.Time:
+		return NewDateValue(r), nil
+	case *time.Duration:
+		return NewDurationValue(r), nil
+	case *float32:
+		return NewFloat32Value(r), nil
+	case *float64:
+		return NewFloat64Value(r), nil
+	case *int:
+		return NewIntValue(r), nil
+	case *net.IP:
+		return NewIPValue(r), nil
+	case *string:
+		return NewStringValue(r), nil
+	case *url.URL:
+		return NewURLValue(r), nil
+	}
+
+	return nil, fmt.Errorf("unsupported value type '%T'", ref)
+}
+
+// ValidateValue checks to see if the given string could be set on the given Value, without changing
+// it. Values that implement ValueValidator validate the string themselves. The Values in this
+// package are copied, and the string is set on the copy instead. Other Values may reference state
+// that a copy would share, so Set is never called on them, and the string is assumed to be valid.
+func ValidateValue(value Value, s string) error {
+	if validator, ok := value.(ValueValidator); ok {
+		return validator.Validate(s)
+	}
+
+	copied, ok := copyValue(value)
+	if !ok {
+		return nil
+	}
+
+	return copied.Set(s)
+}
+
+// DetachValue gets a Value that strings can be set on without changing the given Value, e.g. to
+// check input without applying it. The Values in this package are copied. Other Values are wrapped,
+// so that setting a string only validates it (see ValidateValue), and the wrapper keeps showing the
+// given Value's string.
+func DetachValue(value Value) Value {
+	if value == nil {
+		return nil
+	}
+
+	if copied, ok := copyValue(value); ok {
+		return copied
+	}
+
+	detached := detachedValue{value: value}
+
+	if flag, ok := value.(FlagValue); ok {
+		return &detachedFlagValue{detachedValue: detached, flag: flag}
+	}
+
+	return &detached
+}
+
+// detachedValue is a Value that validates strings set on it against another Value, without
+// changing it.
+type detachedValue struct {
+	value Value
+}
+
+// Set validates the given string against the detached Value.
+func (v *detachedValue) Set(s string) error {
+	return ValidateValue(v.value, s)
+}
+
+// String converts the detached Value to a string.
+func (v *detachedValue) String() string {
+	return v.value.String()
+}
+
+// detachedFlagValue is a detachedValue for a FlagValue.
+type detachedFlagValue struct {
+	detachedValue
+	flag FlagValue
+}
+
+// FlagValue returns the detached FlagValue's value for when it's used as a flag.
+func (v *detachedFlagValue) FlagValue() string {
+	return v.flag.FlagValue()
+}
+
+// copyValue copies the given Value, if it's one of the Values in this package. Each of them is a
+// pointer to a type that Set replaces as a whole, so a shallow copy is enough.
+func copyValue(value Value) (Value, bool) {
+	switch value.(type) {
+	case *BoolValue, *DateValue, *DurationValue, *Float32Value, *Float64Value, *IntValue, *IPValue,
+		*StringValue, *URLValue:
+	default:
+		return nil, false
+	}
+
+	rv := reflect.ValueOf(value)
+	if rv.Kind() != reflect.Ptr || rv.IsNil() {
+		return nil, false
+	}
+
+	scratch := reflect.New(rv.Elem().Type())
+	scratch.Elem().Set(rv.Elem())
+
+	copied, ok := scratch.Interface().(Value)
+
+	return copied, ok
+}
+
+// DefaultValue gets the current value of the given Value as a string, to be shown as the default
+// value of an argument or option (it's recorded in their Default field when they're defined). Zero
+// values (e.g. an empty string, 0, or false) aren't useful to show, so an empty string is returned
+// for them.
+func DefaultValue(value Value) string {
+	if value == nil {
+		return ""
+	}
+
+	str := value.String()
+
+	switch str {
+	case "", "0", "false", "0s", "<nil>", "0001-01-01":
+		return ""
+	}
+
+	return str
+}
+
+// ValueType gets the name of the type of the given Value (e.g. "string", "int", or "duration"), as
+// used in generated documentation and models. Values of other types are described as "custom".
+func ValueType(value Value) string {
+	switch value.(type) {
+	case *BoolValue:
+		return "bool"
+	case *DateValue:
+		return "date"
+	case *DurationValue:
+		return "duration"
+	case *Float32Value:
+		return "float32"
+	case *Float64Value:
+		return "float64"
+	case *IntValue:
+		return "int"
+	case *IPValue:
+		return "ip"
+	case *StringValue:
+		return "string"
+	case *URLValue:
+		return "url"
+	case nil:
+		return ""
+	}
+
+	return "custom"
+}
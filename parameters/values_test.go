@@ -1,8 +1,10 @@
 package parameters_test
 
 import (
+	"fmt"
 	"net"
 	"net/url"
+	"strings"
 	"testing"
 	"time"
 
@@ -672,3 +674,242 @@ func TestUrlValue(t *testing.T) {
 		}
 	})
 }
+
+func TestNewValue(t *testing.T) {
+	t.Run("should create values for supported types", func(t *testing.T) {
+		var b bool
+		var d time.Time
+		var du time.Duration
+		var f32 float32
+		var f64 float64
+		var i int
+		var ip net.IP
+		var s string
+		var u url.URL
+
+		refs := map[interface{}]interface{}{
+			&b:   new(parameters.BoolValue),
+			&d:   new(parameters.DateValue),
+			&du:  new(parameters.DurationValue),
+			&f32: new(parameters.Float32Value),
+			&f64: new(parameters.Float64Value),
+			&i:   new(parameters.IntValue),
+			&ip:  new(parameters.IPValue),
+			&s:   new(parameters.StringValue),
+			&u:   new(parameters.URLValue),
+		}
+
+		for ref, expected := range refs {
+			value, err := parameters.NewValue(ref)
+
+			assert.NoError(t, err)
+			assert.IsType(t, expected, value)
+		}
+	})
+
+	t.Run("should reference the given value", func(t *testing.T) {
+		var s string
+
+		value, err := parameters.NewValue(&s)
+		require.NoError(t, err)
+
+		value.Set("hello")
+
+		assert.Equal(t, "hello", s)
+	})
+
+	t.Run("should return references that implement Value as-is", func(t *testing.T) {
+		var s string
+
+		in := parameters.NewStringValue(&s)
+		out, err := parameters.NewValue(in)
+
+		assert.NoError(t, err)
+		assert.Equal(t, in, out)
+	})
+
+	t.Run("should error for unsupported types", func(t *testing.T) {
+		var c complex64
+
+		_, err := parameters.NewValue(&c)
+
+		assert.Error(t, err)
+	})
+}
+
+func TestValidateValue(t *testing.T) {
+	t.Run("should validate without changing the value", func(t *testing.T) {
+		i := 3
+
+		value := parameters.NewIntValue(&i)
+
+		assert.NoError(t, parameters.ValidateValue(value, "42"))
+		assert.Error(t, parameters.ValidateValue(value, "forty-two"))
+		assert.Equal(t, 3, i)
+	})
+
+	t.Run("should use Validate if the value implements it", func(t *testing.T) {
+		var items []string
+
+		value := &testListValue{ref: &items, valid: "a"}
+
+		assert.NoError(t, parameters.ValidateValue(value, "a"))
+		assert.Error(t, parameters.ValidateValue(value, "b"))
+		assert.Empty(t, items)
+	})
+
+	t.Run("should not set other values", func(t *testing.T) {
+		var items []string
+
+		assert.NoError(t, parameters.ValidateValue(&testRefValue{ref: &items}, "a"))
+		assert.Empty(t, items)
+	})
+}
+
+// testRefValue is a Value that references a list, appending to it when it's set.
+type testRefValue struct {
+	ref *[]string
+}
+
+func (v *testRefValue) Set(s string) error {
+	*v.ref = append(*v.ref, s)
+	return nil
+}
+
+func (v *testRefValue) String() string {
+	return strings.Join(*v.ref, ",")
+}
+
+// testListValue is a testRefValue that only accepts the valid string, if one is given.
+type testListValue struct {
+	ref   *[]string
+	valid string
+}
+
+func (v *testListValue) Set(s string) error {
+	*v.ref = append(*v.ref, s)
+	return nil
+}
+
+func (v *testListValue) String() string {
+	return strings.Join(*v.ref, ",")
+}
+
+func (v *testListValue) Validate(s string) error {
+	if s != v.valid {
+		return fmt.Errorf("invalid value '%s'", s)
+	}
+
+	return nil
+}
+
+func TestDetachValue(t *testing.T) {
+	t.Run("should copy this package's values", func(t *testing.T) {
+		s := "localhost"
+
+		value := parameters.NewStringValue(&s)
+		detached := parameters.DetachValue(value)
+
+		assert.NoError(t, detached.Set("remote"))
+		assert.Equal(t, "remote", detached.String())
+		assert.Equal(t, "localhost", s)
+	})
+
+	t.Run("should only validate strings set on other values", func(t *testing.T) {
+		items := []string{"a"}
+
+		detached := parameters.DetachValue(&testListValue{ref: &items, valid: "a"})
+
+		assert.NoError(t, detached.Set("a"))
+		assert.Error(t, detached.Set("b"))
+		assert.Equal(t, "a", detached.String())
+		assert.Equal(t, []string{"a"}, items)
+	})
+
+	t.Run("should keep flag values usable as flags", func(t *testing.T) {
+		detached := parameters.DetachValue(&testFlagValue{})
+
+		flag, ok := detached.(parameters.FlagValue)
+
+		assert.True(t, ok)
+		assert.Equal(t, "on", flag.FlagValue())
+	})
+
+	t.Run("should return nil for nil values", func(t *testing.T) {
+		assert.Nil(t, parameters.DetachValue(nil))
+	})
+}
+
+// testFlagValue is a FlagValue that can't be set.
+type testFlagValue struct{}
+
+func (v *testFlagValue) Set(s string) error {
+	panic("testFlagValue should not be set")
+}
+
+func (v *testFlagValue) String() string {
+	return ""
+}
+
+func (v *testFlagValue) FlagValue() string {
+	return "on"
+}
+
+func TestDefaultValue(t *testing.T) {
+	t.Run("should return the current value", func(t *testing.T) {
+		s := "localhost"
+		i := 3
+
+		assert.Equal(t, "localhost", parameters.DefaultValue(parameters.NewStringValue(&s)))
+		assert.Equal(t, "3", parameters.DefaultValue(parameters.NewIntValue(&i)))
+	})
+
+	t.Run("should return an empty string for zero values", func(t *testing.T) {
+		var b bool
+		var d time.Time
+		var du time.Duration
+		var i int
+		var ip net.IP
+		var s string
+
+		assert.Equal(t, "", parameters.DefaultValue(parameters.NewBoolValue(&b)))
+		assert.Equal(t, "", parameters.DefaultValue(parameters.NewDateValue(&d)))
+		assert.Equal(t, "", parameters.DefaultValue(parameters.NewDurationValue(&du)))
+		assert.Equal(t, "", parameters.DefaultValue(parameters.NewIntValue(&i)))
+		assert.Equal(t, "", parameters.DefaultValue(parameters.NewIPValue(&ip)))
+		assert.Equal(t, "", parameters.DefaultValue(parameters.NewStringValue(&s)))
+		assert.Equal(t, "", parameters.DefaultValue(nil))
+	})
+}
+
+func TestValueType(t *testing.T) {
+	t.Run("should return the name of the type of built-in values", func(t *testing.T) {
+		var d time.Duration
+		var i int
+		var s string
+		var u url.URL
+
+		assert.Equal(t, "duration", parameters.ValueType(parameters.NewDurationValue(&d)))
+		assert.Equal(t, "int", parameters.ValueType(parameters.NewIntValue(&i)))
+		assert.Equal(t, "string", parameters.ValueType(parameters.NewStringValue(&s)))
+		assert.Equal(t, "url", parameters.ValueType(parameters.NewURLValue(&u)))
+	})
+
+	t.Run("should return custom for other values", func(t *testing.T) {
+		assert.Equal(t, "custom", parameters.ValueType(&customValue{}))
+	})
+
+	t.Run("should return an empty string for nil values", func(t *testing.T) {
+		assert.Equal(t, "", parameters.ValueType(nil))
+	})
+}
+
+type customValue struct{}
+
+func (v *customValue) Set(s string) error {
+	return nil
+}
+
+func (v *customValue) String() string {
+	return ""
+}
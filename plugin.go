@@ -0,0 +1,183 @@
+package console
+
+import (
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+)
+
+// pluginDescription is the description shown in help for external sub-command plugins.
+const pluginDescription = "External plugin."
+
+// findPlugin finds the external sub-command plugin that provides the command with the given name,
+// returning nil if there isn't one. Plugins are executables named `<UsageName>-<command>`. Plugin
+// directories are searched before the PATH in the given environment, and the first plugin found is
+// used.
+func (a *Application) findPlugin(name string, env []string) *Command {
+	if name == "" || strings.HasPrefix(name, "-") || strings.ContainsAny(name, `/\`) {
+		return nil
+	}
+
+	extensions := pluginExtensions(env)
+
+	fileNames := []string{a.UsageName + "-" + name}
+	if extensions != nil {
+		fileNames = nil
+
+		for _, ext := range extensions {
+			fileNames = append(fileNames, a.UsageName+"-"+name+ext)
+		}
+	}
+
+	for _, dir := range a.pluginDirs(env) {
+		for _, fileName := range fileNames {
+			path := filepath.Join(dir, fileName)
+
+			file, err := os.Stat(path)
+			if err != nil {
+				continue
+			}
+
+			if _, ok := a.pluginCommandName(file, extensions); ok {
+				return newPluginCommand(name, path)
+			}
+		}
+	}
+
+	return nil
+}
+
+// findPlugins finds all external sub-command plugins available to the application, so that they
+// can be listed in help. This reads every plugin directory, and every directory on the PATH in the
+// given environment, so it's only done when help is shown; plugins are otherwise found with
+// findPlugin.
+func (a *Application) findPlugins(env []string) []*Command {
+	var plugins []*Command
+
+	extensions := pluginExtensions(env)
+	found := make(map[string]bool)
+
+	for _, dir := range a.pluginDirs(env) {
+		files, err := ioutil.ReadDir(dir)
+		if err != nil {
+			// Directories that don't exist, or can't be read are ignored, as they are by the shell.
+			continue
+		}
+
+		for _, file := range files {
+			name, ok := a.pluginCommandName(file, extensions)
+			if !ok || found[name] {
+				continue
+			}
+
+			found[name] = true
+
+			plugins = append(plugins, newPluginCommand(name, filepath.Join(dir, file.Name())))
+		}
+	}
+
+	return plugins
+}
+
+// pluginDirs gets the directories searched for plugins, in order. These are the plugin directories,
+// followed by those on the PATH in the given environment.
+func (a *Application) pluginDirs(env []string) []string {
+	var dirs []string
+
+	for _, dir := range append(append([]string{}, a.PluginDirs...), filepath.SplitList(lookupEnv(env, "PATH"))...) {
+		if dir != "" {
+			dirs = append(dirs, dir)
+		}
+	}
+
+	return dirs
+}
+
+// pluginCommandName gets the name of the command provided by the given file, if it's an executable
+// plugin. Files are executable if they have an extension in the given list, or if the list is nil,
+// if they have an executable permission bit set.
+func (a *Application) pluginCommandName(file os.FileInfo, extensions []string) (string, bool) {
+	if file.IsDir() {
+		return "", false
+	}
+
+	name := file.Name()
+
+	if extensions == nil && file.Mode()&0111 == 0 {
+		return "", false
+	}
+
+	if extensions != nil {
+		ext := filepath.Ext(name)
+		if ext == "" || !containsString(extensions, strings.ToLower(ext)) {
+			return "", false
+		}
+
+		name = strings.TrimSuffix(name, ext)
+	}
+
+	prefix := a.UsageName + "-"
+	if !strings.HasPrefix(name, prefix) || len(name) == len(prefix) {
+		return "", false
+	}
+
+	return strings.TrimPrefix(name, prefix), true
+}
+
+// pluginExtensions gets the file extensions that make a file executable, in lower-case. On Windows
+// these come from the PATHEXT variable in the given environment. Other platforms use file
+// permissions instead, so nil is returned.
+func pluginExtensions(env []string) []string {
+	if runtime.GOOS != "windows" {
+		return nil
+	}
+
+	pathext := lookupEnv(env, "PATHEXT")
+	if pathext == "" {
+		pathext = ".com;.exe;.bat;.cmd"
+	}
+
+	var extensions []string
+	for _, ext := range filepath.SplitList(strings.ToLower(pathext)) {
+		if ext != "" {
+			extensions = append(extensions, ext)
+		}
+	}
+
+	return extensions
+}
+
+// newPluginCommand creates a command that runs the plugin executable at the given path.
+func newPluginCommand(name string, path string) *Command {
+	return &Command{
+		Name:        name,
+		Description: pluginDescription,
+		plugin:      path,
+	}
+}
+
+// runPlugin runs the given plugin command's executable with the given arguments and environment,
+// passing through the standard streams and exit code. Plugins that don't exit normally (e.g. they
+// are killed by a signal) fail with an exit code of 1.
+func (a *Application) runPlugin(cmd *Command, argv []string, env []string) int {
+	plugin := exec.Command(cmd.plugin, argv...)
+	plugin.Env = env
+	plugin.Stdin = a.Reader
+	plugin.Stdout = a.Writer
+	plugin.Stderr = a.output.ErrWriter
+
+	err := plugin.Run()
+	if exitErr, ok := err.(*exec.ExitError); ok && exitErr.ExitCode() >= 0 {
+		return exitErr.ExitCode()
+	}
+
+	if err != nil {
+		a.showError(err)
+		return 1
+	}
+
+	return 0
+}
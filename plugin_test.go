@@ -0,0 +1,140 @@
+package console_test
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/seeruk/go-console"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPlugins(t *testing.T) {
+	createPluginDir := func(t *testing.T) string {
+		dir, err := ioutil.TempDir("", "go-console-plugins")
+		assert.NoError(t, err)
+
+		script := "#!/bin/sh\necho \"plugin args: $@\"\nexit 3\n"
+
+		err = ioutil.WriteFile(filepath.Join(dir, "testapp-foo"), []byte(script), 0755)
+		assert.NoError(t, err)
+
+		err = ioutil.WriteFile(filepath.Join(dir, "testapp-notexec"), []byte(script), 0644)
+		assert.NoError(t, err)
+
+		return dir
+	}
+
+	createApplication := func(writer *bytes.Buffer, dir string) *console.Application {
+		application := console.NewApplication("seeruk/go-console", "1.2.3+testing")
+		application.UsageName = "testapp"
+		application.Writer = writer
+		application.ErrWriter = ioutil.Discard
+		application.EnablePlugins = true
+		application.PluginDirs = []string{dir}
+
+		return application
+	}
+
+	t.Run("should run plugins, passing through arguments and the exit code", func(t *testing.T) {
+		dir := createPluginDir(t)
+		defer os.RemoveAll(dir)
+
+		writer := bytes.Buffer{}
+		application := createApplication(&writer, dir)
+
+		code := application.Run([]string{"foo", "bar", "--baz", "--help"}, []string{})
+
+		assert.Equal(t, 3, code)
+		assert.Equal(t, "plugin args: bar --baz --help\n", writer.String())
+	})
+
+	t.Run("should list plugins in application help", func(t *testing.T) {
+		dir := createPluginDir(t)
+		defer os.RemoveAll(dir)
+
+		result := console.DescribeApplication(createApplication(&bytes.Buffer{}, dir))
+
+		assert.True(t, strings.Contains(result, "COMMANDS:"), "Expected commands title.")
+		assert.True(t, strings.Contains(result, "External plugin"), "Expected plugin.")
+		assert.False(t, strings.Contains(result, "notexec"), "Expected non-executable file to be ignored.")
+		assert.False(t, strings.Contains(result, dir), "Expected plugin path to not be shown.")
+	})
+
+	t.Run("should not include plugins in generated models", func(t *testing.T) {
+		dir := createPluginDir(t)
+		defer os.RemoveAll(dir)
+
+		model := console.DescribeModel(createApplication(&bytes.Buffer{}, dir))
+
+		assert.Empty(t, model.Commands)
+	})
+
+	t.Run("should prefer commands over plugins", func(t *testing.T) {
+		dir := createPluginDir(t)
+		defer os.RemoveAll(dir)
+
+		writer := bytes.Buffer{}
+		application := createApplication(&writer, dir)
+		application.AddCommand(&console.Command{
+			Name: "foo",
+			Execute: func(input *console.Input, output *console.Output) error {
+				output.Print("command")
+				return nil
+			},
+		})
+
+		code := application.Run([]string{"foo"}, []string{})
+
+		assert.Equal(t, 0, code)
+		assert.Equal(t, "command", writer.String())
+	})
+
+	t.Run("should find plugins on the PATH given to Run", func(t *testing.T) {
+		dir := createPluginDir(t)
+		defer os.RemoveAll(dir)
+
+		writer := bytes.Buffer{}
+		application := createApplication(&writer, dir)
+		application.PluginDirs = nil
+
+		code := application.Run([]string{"foo"}, []string{"PATH=" + dir})
+
+		assert.Equal(t, 3, code)
+
+		application = createApplication(&bytes.Buffer{}, dir)
+		application.PluginDirs = nil
+
+		code = application.Run([]string{"foo"}, []string{})
+
+		assert.Equal(t, 100, code)
+	})
+
+	t.Run("should fail if a plugin is killed by a signal", func(t *testing.T) {
+		dir := createPluginDir(t)
+		defer os.RemoveAll(dir)
+
+		err := ioutil.WriteFile(filepath.Join(dir, "testapp-killed"), []byte("#!/bin/sh\nkill -9 $$\n"), 0755)
+		assert.NoError(t, err)
+
+		code := createApplication(&bytes.Buffer{}, dir).Run([]string{"killed"}, []string{})
+
+		assert.Equal(t, 1, code)
+	})
+
+	t.Run("should not run plugins unless enabled", func(t *testing.T) {
+		dir := createPluginDir(t)
+		defer os.RemoveAll(dir)
+
+		writer := bytes.Buffer{}
+		application := createApplication(&writer, dir)
+		application.EnablePlugins = false
+
+		code := application.Run([]string{"foo"}, []string{})
+
+		assert.Equal(t, 100, code)
+	})
+}
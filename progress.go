@@ -0,0 +1,362 @@
+package console
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+	"time"
+)
+
+// spinnerFrames are the frames of the animation shown for indeterminate progress.
+var spinnerFrames = []string{"⠋", "⠙", "⠹", "⠸", "⠼", "⠴", "⠦", "⠧", "⠇", "⠏"}
+
+// Progress manages the progress bars and spinners shown on an Output. Progress is diagnostic, so
+// it's written to the Output's error writer, keeping it out of any data written to the regular
+// writer. When the error writer is a terminal, all active bars are drawn together as a block that is
+// redrawn in place, and any other output written while bars are active is written above the block.
+// When it is not a terminal, progress is instead written as plain lines of text, at most once per
+// Interval for each bar (as well as when a bar starts and finishes).
+type Progress struct {
+	// The minimum time between plain-text progress lines for each bar, when the output is not a
+	// terminal.
+	Interval time.Duration
+	// The width of the bar part of progress bars, in columns.
+	BarWidth int
+	// Whether or not bars are drawn in place, as on a terminal. This is detected automatically when
+	// the Progress is created, but may be overridden before any bars are added.
+	Terminal bool
+
+	output *Output
+	mu     sync.Mutex
+	bars   []*ProgressBar
+	lines  int
+	frame  int
+	stop   chan struct{}
+}
+
+// ProgressBar represents the progress of a single task. A ProgressBar with a total of 0 or less is
+// indeterminate, and is shown as a spinner. ProgressBar methods are safe for concurrent use.
+type ProgressBar struct {
+	progress *Progress
+	label    string
+	total    int64
+	current  int64
+	started  time.Time
+	lastLine time.Time
+	finished bool
+}
+
+// Progress gets the progress manager for this Output, creating it if necessary. It is safe for
+// concurrent use.
+func (o *Output) Progress() *Progress {
+	o.progressMu.Lock()
+	defer o.progressMu.Unlock()
+
+	if o.progress == nil {
+		o.progress = &Progress{
+			Interval: 5 * time.Second,
+			BarWidth: 30,
+			output:   o,
+			Terminal: isTerminal(o.ErrWriter),
+		}
+	}
+
+	return o.progress
+}
+
+// activeProgress gets the progress manager for this Output, or nil if progress hasn't been shown.
+func (o *Output) activeProgress() *Progress {
+	o.progressMu.Lock()
+	defer o.progressMu.Unlock()
+
+	return o.progress
+}
+
+// NewProgressBar starts a new determinate progress bar with the given label and total.
+func (o *Output) NewProgressBar(label string, total int64) *ProgressBar {
+	return o.Progress().add(label, total)
+}
+
+// NewSpinner starts a new indeterminate progress bar (a spinner) with the given label.
+func (o *Output) NewSpinner(label string) *ProgressBar {
+	return o.Progress().add(label, 0)
+}
+
+// Add increases the progress of the bar by the given amount.
+func (b *ProgressBar) Add(n int64) {
+	b.progress.mu.Lock()
+	defer b.progress.mu.Unlock()
+
+	b.current += n
+	b.progress.update(b)
+}
+
+// Set sets the progress of the bar to the given amount.
+func (b *ProgressBar) Set(n int64) {
+	b.progress.mu.Lock()
+	defer b.progress.mu.Unlock()
+
+	b.current = n
+	b.progress.update(b)
+}
+
+// SetLabel changes the label shown with the bar.
+func (b *ProgressBar) SetLabel(label string) {
+	b.progress.mu.Lock()
+	defer b.progress.mu.Unlock()
+
+	b.label = label
+}
+
+// Finish marks the bar as complete, showing it's final state. Calling Finish more than once has no
+// effect.
+func (b *ProgressBar) Finish() {
+	b.progress.mu.Lock()
+	defer b.progress.mu.Unlock()
+
+	if b.finished {
+		return
+	}
+
+	b.finished = true
+
+	if b.total > 0 && b.current < b.total {
+		b.current = b.total
+	}
+
+	b.progress.finish(b)
+}
+
+// Stop stops drawing any bars that haven't been finished, leaving them as they were last shown. It is
+// called by the Application once a command has been executed, so that bars a command didn't finish
+// aren't left animating.
+func (p *Progress) Stop() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.bars = nil
+	p.lines = 0
+
+	if p.stop != nil {
+		close(p.stop)
+		p.stop = nil
+	}
+}
+
+// add starts a new bar.
+func (p *Progress) add(label string, total int64) *ProgressBar {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	bar := &ProgressBar{
+		progress: p,
+		label:    label,
+		total:    total,
+		started:  time.Now(),
+	}
+
+	p.bars = append(p.bars, bar)
+
+	if !p.Terminal {
+		p.writeLine(bar, time.Now())
+		return bar
+	}
+
+	// On terminals, a goroutine redraws the bars periodically so that spinners animate, and so that
+	// throughput and ETA stay up to date.
+	if p.stop == nil {
+		p.stop = make(chan struct{})
+		go p.animate(p.stop)
+	}
+
+	p.redraw()
+
+	return bar
+}
+
+// update handles a change in a bar's progress.
+func (p *Progress) update(bar *ProgressBar) {
+	if p.Terminal {
+		// Terminals are redrawn periodically anyway.
+		return
+	}
+
+	now := time.Now()
+	if now.Sub(bar.lastLine) >= p.Interval {
+		p.writeLine(bar, now)
+	}
+}
+
+// finish handles a bar being finished, removing it from the active bars.
+func (p *Progress) finish(bar *ProgressBar) {
+	for i, b := range p.bars {
+		if b == bar {
+			p.bars = append(p.bars[:i], p.bars[i+1:]...)
+			break
+		}
+	}
+
+	if !p.Terminal {
+		p.writeLine(bar, time.Now())
+		return
+	}
+
+	// Finished bars are written above the block of active bars, so they stay visible.
+	p.clear()
+	p.writeRaw(p.describe(bar, time.Now()) + "\n")
+	p.redraw()
+
+	if len(p.bars) == 0 && p.stop != nil {
+		close(p.stop)
+		p.stop = nil
+	}
+}
+
+// animate redraws the active bars periodically, until stopped.
+func (p *Progress) animate(stop chan struct{}) {
+	ticker := time.NewTicker(100 * time.Millisecond)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-stop:
+			return
+		case <-ticker.C:
+			p.mu.Lock()
+
+			// Check again, in case the bars were finished while waiting for the lock.
+			select {
+			case <-stop:
+				p.mu.Unlock()
+				return
+			default:
+			}
+
+			p.frame++
+			p.redraw()
+			p.mu.Unlock()
+		}
+	}
+}
+
+// interleave writes the given text to the given writer above any active bars, so that regular
+// output and progress can be mixed safely when they're shown on the same terminal. If there are no
+// active bars on a terminal, the text is just written.
+func (p *Progress) interleave(writer io.Writer, text string) (int, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if !p.Terminal || len(p.bars) == 0 {
+		return io.WriteString(writer, text)
+	}
+
+	p.clear()
+	n, err := io.WriteString(writer, text)
+
+	// Bars must always start on a new line, so they don't overwrite partial lines.
+	if !strings.HasSuffix(text, "\n") {
+		p.writeRaw("\n")
+	}
+
+	p.redraw()
+
+	return n, err
+}
+
+// clear erases the block of active bars from the terminal.
+func (p *Progress) clear() {
+	if p.lines > 0 {
+		p.writeRaw(fmt.Sprintf("\x1b[%dA\x1b[J", p.lines))
+		p.lines = 0
+	}
+}
+
+// redraw draws the block of active bars, replacing the previously drawn block.
+func (p *Progress) redraw() {
+	var block strings.Builder
+
+	if p.lines > 0 {
+		block.WriteString(fmt.Sprintf("\x1b[%dA", p.lines))
+	}
+
+	now := time.Now()
+	for _, bar := range p.bars {
+		block.WriteString("\r\x1b[2K" + p.describe(bar, now) + "\n")
+	}
+
+	// Remove any lines left over from bars that are no longer active.
+	block.WriteString("\x1b[J")
+
+	p.lines = len(p.bars)
+	p.writeRaw(block.String())
+}
+
+// writeLine writes the state of the given bar as a plain line of text.
+func (p *Progress) writeLine(bar *ProgressBar, now time.Time) {
+	bar.lastLine = now
+	p.writeRaw(p.describe(bar, now) + "\n")
+}
+
+// writeRaw writes the given text to the output's error writer, rendering any markup.
+func (p *Progress) writeRaw(text string) {
+	writer := p.output.ErrWriter
+
+	io.WriteString(writer, p.output.formatter.Format(text, p.output.IsDecorated(writer)))
+}
+
+// describe describes the current state of the given bar as a single line.
+func (p *Progress) describe(bar *ProgressBar, now time.Time) string {
+	elapsed := now.Sub(bar.started)
+
+	var rate float64
+	if elapsed > 0 {
+		rate = float64(bar.current) / elapsed.Seconds()
+	}
+
+	if bar.total <= 0 {
+		status := spinnerFrames[p.frame%len(spinnerFrames)]
+		if !p.Terminal || bar.finished {
+			status = "-"
+		}
+
+		line := fmt.Sprintf("%s %s %d", status, bar.label, bar.current)
+		if bar.finished {
+			return line + fmt.Sprintf(" (done in %s)", elapsed.Round(time.Millisecond))
+		}
+
+		return line + fmt.Sprintf(" (%.1f/s, %s elapsed)", rate, elapsed.Round(time.Second))
+	}
+
+	ratio := float64(bar.current) / float64(bar.total)
+	if ratio > 1 {
+		ratio = 1
+	} else if ratio < 0 {
+		ratio = 0
+	}
+
+	line := fmt.Sprintf("%s %3.0f%% %d/%d", bar.label, ratio*100, bar.current, bar.total)
+
+	if p.Terminal {
+		filled := int(ratio * float64(p.BarWidth))
+		drawn := strings.Repeat("=", filled)
+
+		if filled < p.BarWidth {
+			drawn += ">" + strings.Repeat(" ", p.BarWidth-filled-1)
+		}
+
+		line = fmt.Sprintf("%s [%s] %3.0f%% %d/%d", bar.label, drawn, ratio*100, bar.current, bar.total)
+	}
+
+	if bar.finished {
+		return line + fmt.Sprintf(" (done in %s)", elapsed.Round(time.Millisecond))
+	}
+
+	// There's no time left to estimate once the bar is full.
+	if rate > 0 && bar.current < bar.total {
+		eta := time.Duration(float64(bar.total-bar.current) / rate * float64(time.Second))
+		line += fmt.Sprintf(" (%.1f/s, ETA %s)", rate, eta.Round(time.Second))
+	}
+
+	return line
+}
@@ -0,0 +1,196 @@
+package console_test
+
+import (
+	"bytes"
+	"io/ioutil"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/seeruk/go-console"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestProgress(t *testing.T) {
+	t.Run("should not draw in place if the output is not a terminal", func(t *testing.T) {
+		output := console.NewOutput(&bytes.Buffer{})
+
+		assert.False(t, output.Progress().Terminal)
+	})
+
+	t.Run("should write plain lines when not on a terminal", func(t *testing.T) {
+		buffer := bytes.Buffer{}
+		output := console.NewOutput(&buffer)
+		output.Progress().Interval = 0
+
+		bar := output.NewProgressBar("upload", 4)
+		bar.Add(2)
+		bar.Finish()
+
+		lines := strings.Split(strings.TrimSpace(buffer.String()), "\n")
+
+		assert.Len(t, lines, 3)
+		assert.True(t, strings.HasPrefix(lines[0], "upload   0% 0/4"), "Unexpected line: %s", lines[0])
+		assert.True(t, strings.HasPrefix(lines[1], "upload  50% 2/4"), "Unexpected line: %s", lines[1])
+		assert.True(t, strings.HasPrefix(lines[2], "upload 100% 4/4 (done in"), "Unexpected line: %s", lines[2])
+		assert.NotContains(t, buffer.String(), "\x1b[")
+	})
+
+	t.Run("should only write plain lines once per interval", func(t *testing.T) {
+		buffer := bytes.Buffer{}
+		output := console.NewOutput(&buffer)
+		output.Progress().Interval = time.Hour
+
+		bar := output.NewProgressBar("upload", 100)
+		for i := 0; i < 100; i++ {
+			bar.Add(1)
+		}
+
+		bar.Finish()
+
+		assert.Equal(t, 2, strings.Count(buffer.String(), "\n"))
+	})
+
+	t.Run("should write spinners as plain lines when not on a terminal", func(t *testing.T) {
+		buffer := bytes.Buffer{}
+		output := console.NewOutput(&buffer)
+
+		spinner := output.NewSpinner("migrating")
+		spinner.Add(3)
+		spinner.Finish()
+
+		assert.Contains(t, buffer.String(), "- migrating 0")
+		assert.Contains(t, buffer.String(), "- migrating 3 (done in")
+	})
+
+	t.Run("should draw bars in place on a terminal", func(t *testing.T) {
+		buffer := bytes.Buffer{}
+		output := console.NewOutput(&buffer)
+		output.Progress().Terminal = true
+		output.Progress().BarWidth = 4
+
+		bar := output.NewProgressBar("upload", 2)
+		bar.Add(1)
+		bar.Finish()
+
+		assert.Contains(t, buffer.String(), "\r\x1b[2Kupload [>   ]   0% 0/2")
+		assert.Contains(t, buffer.String(), "upload [====] 100% 2/2 (done in")
+	})
+
+	t.Run("should draw bars with values outside of their total", func(t *testing.T) {
+		buffer := lockedBuffer{}
+		output := console.NewOutput(&buffer)
+		output.Progress().Terminal = true
+		output.Progress().BarWidth = 4
+
+		over := output.NewProgressBar("download", 100)
+		under := output.NewProgressBar("upload", 100)
+
+		time.Sleep(time.Millisecond)
+
+		over.Set(150)
+		under.Set(-10)
+
+		assert.NotPanics(t, func() {
+			output.Println("hello")
+		})
+
+		assert.Contains(t, buffer.String(), "download [====] 100% 150/100")
+		assert.NotContains(t, buffer.String(), "ETA")
+		assert.Contains(t, buffer.String(), "upload [>   ]   0% -10/100")
+
+		over.Finish()
+		under.Finish()
+	})
+
+	t.Run("should write regular output above bars on a terminal", func(t *testing.T) {
+		buffer := bytes.Buffer{}
+		output := console.NewOutput(&buffer)
+		output.Progress().Terminal = true
+
+		first := output.NewProgressBar("first", 2)
+		second := output.NewSpinner("second")
+
+		output.Println("hello")
+
+		first.Finish()
+		second.Finish()
+
+		result := buffer.String()
+		hello := strings.Index(result, "hello\n")
+
+		assert.True(t, hello > 0, "Expected regular output.")
+		assert.Contains(t, result[:hello], "\x1b[2A\x1b[J", "Expected bars to be cleared first.")
+		assert.Contains(t, result[hello:], "first", "Expected bars to be redrawn.")
+	})
+	t.Run("should write progress to the error writer", func(t *testing.T) {
+		buffer := bytes.Buffer{}
+		errBuffer := bytes.Buffer{}
+		output := console.NewOutput(&buffer)
+		output.ErrWriter = &errBuffer
+
+		bar := output.NewProgressBar("upload", 1)
+		output.Println("data")
+		bar.Finish()
+
+		assert.Equal(t, "data\n", buffer.String())
+		assert.Contains(t, errBuffer.String(), "upload 100% 1/1 (done in")
+	})
+
+	t.Run("should be safe to start bars from multiple goroutines", func(t *testing.T) {
+		output := console.NewOutput(ioutil.Discard)
+		output.Progress().Terminal = true
+
+		var wg sync.WaitGroup
+
+		for i := 0; i < 10; i++ {
+			wg.Add(1)
+
+			go func() {
+				defer wg.Done()
+
+				bar := output.NewProgressBar("upload", 10)
+				bar.Add(5)
+				output.Println("hello")
+				bar.Finish()
+			}()
+		}
+
+		wg.Wait()
+	})
+
+	t.Run("should stop drawing bars that were not finished", func(t *testing.T) {
+		buffer := lockedBuffer{}
+		output := console.NewOutput(&buffer)
+		output.Progress().Terminal = true
+
+		output.NewSpinner("waiting")
+		output.Progress().Stop()
+
+		written := buffer.String()
+		time.Sleep(250 * time.Millisecond)
+
+		assert.Equal(t, written, buffer.String())
+	})
+}
+
+// lockedBuffer is a bytes.Buffer that is safe to write to, and read from concurrently.
+type lockedBuffer struct {
+	mu     sync.Mutex
+	buffer bytes.Buffer
+}
+
+func (b *lockedBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	return b.buffer.Write(p)
+}
+
+func (b *lockedBuffer) String() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	return b.buffer.String()
+}
@@ -0,0 +1,342 @@
+package console
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// ErrNonInteractive is returned by prompts that can't be answered because input is not
+// interactive, and there is no default answer to use instead.
+var ErrNonInteractive = errors.New("cannot prompt for input, input is not interactive")
+
+// TextPrompt asks for a line of text.
+type TextPrompt struct {
+	// The question to ask.
+	Message string
+	// The answer used if nothing is entered, or if input is not interactive.
+	Default string
+	// Function to validate the answer. If an error is returned it is shown, and the question is
+	// asked again.
+	Validate func(answer string) error
+}
+
+// PasswordPrompt asks for a line of text, without showing what is typed.
+type PasswordPrompt struct {
+	// The question to ask.
+	Message string
+	// Function to validate the answer. If an error is returned it is shown, and the question is
+	// asked again.
+	Validate func(answer string) error
+}
+
+// ConfirmPrompt asks a yes or no question.
+type ConfirmPrompt struct {
+	// The question to ask.
+	Message string
+	// The answer used if nothing is entered, or if input is not interactive.
+	Default bool
+}
+
+// SelectPrompt asks for one option to be chosen from a list.
+type SelectPrompt struct {
+	// The question to ask.
+	Message string
+	// The options to choose from.
+	Options []string
+	// The option used if nothing is entered, or if input is not interactive. If empty, an option
+	// must be chosen.
+	Default string
+}
+
+// MultiSelectPrompt asks for any number of options to be chosen from a list.
+type MultiSelectPrompt struct {
+	// The question to ask.
+	Message string
+	// The options to choose from.
+	Options []string
+	// The options used if nothing is entered, or if input is not interactive.
+	Default []string
+}
+
+// Prompter asks questions, reading answers from an Input's reader, and writing questions to an
+// Output's error writer (so that questions don't end up in piped output). If input is not
+// interactive, then defaults are used where they're available, otherwise ErrNonInteractive is
+// returned.
+type Prompter struct {
+	// Whether or not to ask questions. This is detected automatically from the input when the
+	// Prompter is created, but can be overridden (e.g. to script answers in tests).
+	Interactive bool
+
+	input  *Input
+	output *Output
+}
+
+// NewPrompter creates a new Prompter that uses the given input and output.
+func NewPrompter(input *Input, output *Output) *Prompter {
+	return &Prompter{
+		Interactive: input.IsTerminal(),
+		input:       input,
+		output:      output,
+	}
+}
+
+// Text asks for a line of text.
+func (p *Prompter) Text(prompt TextPrompt) (string, error) {
+	if !p.Interactive {
+		return p.nonInteractive(prompt.Default, prompt.Default != "")
+	}
+
+	message := prompt.Message
+	if prompt.Default != "" {
+		message += fmt.Sprintf(" [<comment>%s</comment>]", EscapeMarkup(prompt.Default))
+	}
+
+	for {
+		answer, err := p.ask(message, false)
+		if err != nil {
+			return "", err
+		}
+
+		if answer == "" {
+			answer = prompt.Default
+		}
+
+		if p.validate(answer, prompt.Validate) {
+			return answer, nil
+		}
+	}
+}
+
+// Password asks for a line of text, without showing what is typed. Typed input is only hidden on
+// platforms that support it, when the input is a terminal.
+func (p *Prompter) Password(prompt PasswordPrompt) (string, error) {
+	if !p.Interactive {
+		return p.nonInteractive("", false)
+	}
+
+	for {
+		answer, err := p.ask(prompt.Message, true)
+		if err != nil {
+			return "", err
+		}
+
+		if p.validate(answer, prompt.Validate) {
+			return answer, nil
+		}
+	}
+}
+
+// Confirm asks a yes or no question.
+func (p *Prompter) Confirm(prompt ConfirmPrompt) (bool, error) {
+	if !p.Interactive {
+		return prompt.Default, nil
+	}
+
+	message := prompt.Message + " [y/N]"
+	if prompt.Default {
+		message = prompt.Message + " [Y/n]"
+	}
+
+	for {
+		answer, err := p.ask(message, false)
+		if err != nil {
+			return false, err
+		}
+
+		switch strings.ToLower(answer) {
+		case "":
+			return prompt.Default, nil
+		case "y", "yes":
+			return true, nil
+		case "n", "no":
+			return false, nil
+		}
+
+		p.output.Errorln("<error>Please answer yes or no.</error>")
+	}
+}
+
+// Select asks for one option to be chosen from a list. The chosen option is returned.
+func (p *Prompter) Select(prompt SelectPrompt) (string, error) {
+	if !p.Interactive {
+		return p.nonInteractive(prompt.Default, prompt.Default != "")
+	}
+
+	message := prompt.Message
+	if prompt.Default != "" {
+		message += fmt.Sprintf(" [<comment>%s</comment>]", EscapeMarkup(prompt.Default))
+	}
+
+	for {
+		p.describeOptions(prompt.Options)
+
+		answer, err := p.ask(message, false)
+		if err != nil {
+			return "", err
+		}
+
+		if answer == "" && prompt.Default != "" {
+			return prompt.Default, nil
+		}
+
+		if option, ok := p.findOption(prompt.Options, answer); ok {
+			return option, nil
+		}
+
+		p.output.Errorf("<error>Invalid choice '%s'.</error>\n", EscapeMarkup(answer))
+	}
+}
+
+// MultiSelect asks for any number of options to be chosen from a list. Options are chosen by
+// entering a comma-separated list. The chosen options are returned, in the order they were given.
+func (p *Prompter) MultiSelect(prompt MultiSelectPrompt) ([]string, error) {
+	if !p.Interactive {
+		return prompt.Default, nil
+	}
+
+	message := prompt.Message + " (comma-separated)"
+	if len(prompt.Default) > 0 {
+		message += fmt.Sprintf(" [<comment>%s</comment>]", EscapeMarkup(strings.Join(prompt.Default, ",")))
+	}
+
+Ask:
+	for {
+		p.describeOptions(prompt.Options)
+
+		answer, err := p.ask(message, false)
+		if err != nil {
+			return nil, err
+		}
+
+		if answer == "" {
+			return prompt.Default, nil
+		}
+
+		var chosen []string
+
+		for _, part := range strings.Split(answer, ",") {
+			option, ok := p.findOption(prompt.Options, strings.TrimSpace(part))
+			if !ok {
+				p.output.Errorf("<error>Invalid choice '%s'.</error>\n", EscapeMarkup(strings.TrimSpace(part)))
+				continue Ask
+			}
+
+			chosen = append(chosen, option)
+		}
+
+		return chosen, nil
+	}
+}
+
+// ask writes the given question, and reads a line of input as the answer. If hidden is true, then
+// typed input is not shown if possible.
+func (p *Prompter) ask(message string, hidden bool) (string, error) {
+	p.output.Errorf("<info>?</info> %s: ", message)
+
+	if file, ok := p.input.GetReader().(*os.File); ok && hidden && isTerminal(file) {
+		restore, err := disableEcho(file)
+		if err != nil {
+			return "", err
+		}
+
+		defer func() {
+			restore()
+
+			// The newline typed by the user isn't shown, so we need to add one.
+			p.output.Errorln()
+		}()
+	}
+
+	answer, err := p.readLine()
+
+	// Hidden input is usually a secret, so any whitespace in it is kept, as it may be significant.
+	if !hidden {
+		answer = strings.TrimSpace(answer)
+	}
+
+	return answer, err
+}
+
+// readLine reads a single line from the input reader, without the line terminator. Input is read
+// one byte at a time, so that nothing is buffered beyond the end of the line, leaving the rest of
+// the input for later prompts (or the command itself) to read.
+func (p *Prompter) readLine() (string, error) {
+	var line []byte
+
+	reader := p.input.GetReader()
+	buf := make([]byte, 1)
+
+	for {
+		n, err := reader.Read(buf)
+		if n > 0 {
+			if buf[0] == '\n' {
+				break
+			}
+
+			line = append(line, buf[0])
+		}
+
+		if err == io.EOF && len(line) > 0 {
+			break
+		}
+
+		if err == io.EOF {
+			return "", io.ErrUnexpectedEOF
+		}
+
+		if err != nil {
+			return "", err
+		}
+	}
+
+	return strings.TrimSuffix(string(line), "\r"), nil
+}
+
+// validate validates an answer with the given validation function, showing any error.
+func (p *Prompter) validate(answer string, validate func(string) error) bool {
+	if validate == nil {
+		return true
+	}
+
+	if err := validate(answer); err != nil {
+		p.output.Errorf("<error>%s</error>\n", EscapeMarkup(err.Error()))
+		return false
+	}
+
+	return true
+}
+
+// nonInteractive returns the given default if there is one, otherwise ErrNonInteractive.
+func (p *Prompter) nonInteractive(def string, hasDefault bool) (string, error) {
+	if !hasDefault {
+		return "", ErrNonInteractive
+	}
+
+	return def, nil
+}
+
+// describeOptions writes a numbered list of options.
+func (p *Prompter) describeOptions(options []string) {
+	for i, option := range options {
+		p.output.Errorf("  [<comment>%d</comment>] %s\n", i+1, EscapeMarkup(option))
+	}
+}
+
+// findOption finds an option by it's number in the list (starting at 1), or by it's value.
+func (p *Prompter) findOption(options []string, answer string) (string, bool) {
+	if i, err := strconv.Atoi(answer); err == nil && i > 0 && i <= len(options) {
+		return options[i-1], true
+	}
+
+	for _, option := range options {
+		if option == answer {
+			return option, true
+		}
+	}
+
+	return "", false
+}
@@ -0,0 +1,244 @@
+package console_test
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/seeruk/go-console"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPrompter(t *testing.T) {
+	createPrompter := func(answers string, buffer *bytes.Buffer) *console.Prompter {
+		input := &console.Input{Reader: strings.NewReader(answers)}
+		output := console.NewOutput(buffer)
+
+		prompter := console.NewPrompter(input, output)
+		prompter.Interactive = true
+
+		return prompter
+	}
+
+	t.Run("should not be interactive if input is not a terminal", func(t *testing.T) {
+		input := &console.Input{Reader: strings.NewReader("")}
+
+		prompter := console.NewPrompter(input, console.NewOutput(&bytes.Buffer{}))
+
+		assert.False(t, prompter.Interactive)
+	})
+
+	t.Run("Text()", func(t *testing.T) {
+		t.Run("should return the answer", func(t *testing.T) {
+			buffer := bytes.Buffer{}
+			prompter := createPrompter("Alice\n", &buffer)
+
+			answer, err := prompter.Text(console.TextPrompt{Message: "Name"})
+
+			assert.NoError(t, err)
+			assert.Equal(t, "Alice", answer)
+			assert.Equal(t, "? Name: ", buffer.String())
+		})
+
+		t.Run("should use the default if nothing is entered", func(t *testing.T) {
+			buffer := bytes.Buffer{}
+			prompter := createPrompter("\n", &buffer)
+
+			answer, err := prompter.Text(console.TextPrompt{Message: "Name", Default: "World"})
+
+			assert.NoError(t, err)
+			assert.Equal(t, "World", answer)
+			assert.Equal(t, "? Name [World]: ", buffer.String())
+		})
+
+		t.Run("should ask again if validation fails", func(t *testing.T) {
+			buffer := bytes.Buffer{}
+			prompter := createPrompter("\nAlice\n", &buffer)
+
+			answer, err := prompter.Text(console.TextPrompt{
+				Message: "Name",
+				Validate: func(answer string) error {
+					if answer == "" {
+						return errors.New("a name is required")
+					}
+
+					return nil
+				},
+			})
+
+			assert.NoError(t, err)
+			assert.Equal(t, "Alice", answer)
+			assert.Contains(t, buffer.String(), "a name is required")
+		})
+
+		t.Run("should use the default if not interactive", func(t *testing.T) {
+			prompter := createPrompter("", &bytes.Buffer{})
+			prompter.Interactive = false
+
+			answer, err := prompter.Text(console.TextPrompt{Message: "Name", Default: "World"})
+
+			assert.NoError(t, err)
+			assert.Equal(t, "World", answer)
+		})
+
+		t.Run("should fail if not interactive and there is no default", func(t *testing.T) {
+			prompter := createPrompter("", &bytes.Buffer{})
+			prompter.Interactive = false
+
+			_, err := prompter.Text(console.TextPrompt{Message: "Name"})
+
+			assert.Equal(t, console.ErrNonInteractive, err)
+		})
+
+		t.Run("should fail if input ends before an answer is given", func(t *testing.T) {
+			prompter := createPrompter("", &bytes.Buffer{})
+
+			_, err := prompter.Text(console.TextPrompt{Message: "Name"})
+
+			assert.Equal(t, io.ErrUnexpectedEOF, err)
+		})
+
+		t.Run("should not consume input beyond the answer", func(t *testing.T) {
+			prompter := createPrompter("Alice\nBob\n", &bytes.Buffer{})
+
+			first, _ := prompter.Text(console.TextPrompt{Message: "First"})
+			second, _ := prompter.Text(console.TextPrompt{Message: "Second"})
+
+			assert.Equal(t, "Alice", first)
+			assert.Equal(t, "Bob", second)
+		})
+	})
+
+	t.Run("Password()", func(t *testing.T) {
+		t.Run("should return the answer", func(t *testing.T) {
+			prompter := createPrompter("hunter2\n", &bytes.Buffer{})
+
+			answer, err := prompter.Password(console.PasswordPrompt{Message: "Password"})
+
+			assert.NoError(t, err)
+			assert.Equal(t, "hunter2", answer)
+		})
+
+		t.Run("should keep leading and trailing whitespace", func(t *testing.T) {
+			prompter := createPrompter(" hunter2 \r\n", &bytes.Buffer{})
+
+			answer, err := prompter.Password(console.PasswordPrompt{Message: "Password"})
+
+			assert.NoError(t, err)
+			assert.Equal(t, " hunter2 ", answer)
+		})
+
+		t.Run("should fail if not interactive", func(t *testing.T) {
+			prompter := createPrompter("hunter2\n", &bytes.Buffer{})
+			prompter.Interactive = false
+
+			_, err := prompter.Password(console.PasswordPrompt{Message: "Password"})
+
+			assert.Equal(t, console.ErrNonInteractive, err)
+		})
+	})
+
+	t.Run("Confirm()", func(t *testing.T) {
+		t.Run("should accept yes and no answers", func(t *testing.T) {
+			prompter := createPrompter("y\nNo\n\n", &bytes.Buffer{})
+
+			first, _ := prompter.Confirm(console.ConfirmPrompt{Message: "Continue?"})
+			second, _ := prompter.Confirm(console.ConfirmPrompt{Message: "Continue?"})
+			third, _ := prompter.Confirm(console.ConfirmPrompt{Message: "Continue?", Default: true})
+
+			assert.True(t, first)
+			assert.False(t, second)
+			assert.True(t, third)
+		})
+
+		t.Run("should ask again if the answer is invalid", func(t *testing.T) {
+			buffer := bytes.Buffer{}
+			prompter := createPrompter("maybe\nyes\n", &buffer)
+
+			answer, err := prompter.Confirm(console.ConfirmPrompt{Message: "Continue?"})
+
+			assert.NoError(t, err)
+			assert.True(t, answer)
+			assert.Contains(t, buffer.String(), "Please answer yes or no.")
+		})
+
+		t.Run("should use the default if not interactive", func(t *testing.T) {
+			prompter := createPrompter("", &bytes.Buffer{})
+			prompter.Interactive = false
+
+			answer, err := prompter.Confirm(console.ConfirmPrompt{Message: "Continue?", Default: true})
+
+			assert.NoError(t, err)
+			assert.True(t, answer)
+		})
+	})
+
+	t.Run("Select()", func(t *testing.T) {
+		t.Run("should accept an option number or value", func(t *testing.T) {
+			buffer := bytes.Buffer{}
+			prompter := createPrompter("2\nred\n", &buffer)
+			prompt := console.SelectPrompt{Message: "Colour", Options: []string{"red", "green"}}
+
+			first, _ := prompter.Select(prompt)
+			second, _ := prompter.Select(prompt)
+
+			assert.Equal(t, "green", first)
+			assert.Equal(t, "red", second)
+			assert.Contains(t, buffer.String(), "  [1] red\n  [2] green\n")
+		})
+
+		t.Run("should ask again if the choice is invalid", func(t *testing.T) {
+			buffer := bytes.Buffer{}
+			prompter := createPrompter("3\n1\n", &buffer)
+
+			answer, err := prompter.Select(console.SelectPrompt{Message: "Colour", Options: []string{"red", "green"}})
+
+			assert.NoError(t, err)
+			assert.Equal(t, "red", answer)
+			assert.Contains(t, buffer.String(), "Invalid choice '3'.")
+		})
+
+		t.Run("should use the default if not interactive", func(t *testing.T) {
+			prompter := createPrompter("", &bytes.Buffer{})
+			prompter.Interactive = false
+
+			answer, err := prompter.Select(console.SelectPrompt{
+				Message: "Colour",
+				Options: []string{"red", "green"},
+				Default: "green",
+			})
+
+			assert.NoError(t, err)
+			assert.Equal(t, "green", answer)
+		})
+	})
+
+	t.Run("MultiSelect()", func(t *testing.T) {
+		t.Run("should accept a list of options", func(t *testing.T) {
+			prompter := createPrompter("3, red\n", &bytes.Buffer{})
+
+			answer, err := prompter.MultiSelect(console.MultiSelectPrompt{
+				Message: "Colours",
+				Options: []string{"red", "green", "blue"},
+			})
+
+			assert.NoError(t, err)
+			assert.Equal(t, []string{"blue", "red"}, answer)
+		})
+
+		t.Run("should use the default if nothing is entered", func(t *testing.T) {
+			prompter := createPrompter("\n", &bytes.Buffer{})
+
+			answer, err := prompter.MultiSelect(console.MultiSelectPrompt{
+				Message: "Colours",
+				Options: []string{"red", "green", "blue"},
+				Default: []string{"green"},
+			})
+
+			assert.NoError(t, err)
+			assert.Equal(t, []string{"green"}, answer)
+		})
+	})
+}
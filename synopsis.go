@@ -0,0 +1,161 @@
+package console
+
+import (
+	"strings"
+
+	"github.com/seeruk/go-console/parameters"
+)
+
+// Styles of the parts of a usage synopsis.
+const (
+	// synopsisPlain is punctuation, like brackets, and the `=` between an option and it's value.
+	synopsisPlain synopsisStyle = iota
+	// synopsisLiteral is text that's typed as-is, like command names, and option names.
+	synopsisLiteral
+	// synopsisPlaceholder is text that's replaced by a value, like argument names.
+	synopsisPlaceholder
+)
+
+// synopsisStyle represents how a part of a usage synopsis should be shown, e.g. in a man page.
+type synopsisStyle int
+
+// synopsisPart is a part of a word in a usage synopsis.
+type synopsisPart struct {
+	text  string
+	style synopsisStyle
+}
+
+// synopsisWord is a word in a usage synopsis, e.g. `[-f]`, or `--dsn=DSN`.
+type synopsisWord []synopsisPart
+
+// String gets the word as plain text.
+func (w synopsisWord) String() string {
+	var text string
+	for _, part := range w {
+		text += part.text
+	}
+
+	return text
+}
+
+// describeSynopsis describes how to run the given command (or the application itself, if the
+// command is nil), found at the given path, as a list of words. For example:
+//
+//	app db migrate [-f] --dsn=DSN [STEPS]
+//
+// Every option is shown, in the order they were defined, unless the synopsis would be wider than
+// the given width. Then, options that aren't required are collapsed into a single `[OPTIONS]`. The
+// commands given are those that can be run after this one, which are shown as `COMMAND`, unless
+// the command can be executed with arguments of it's own.
+func describeSynopsis(app *Application, cmd *Command, path []string, args []parameters.Argument, opts []parameters.Option, commands []*Command, width int) []synopsisWord {
+	var words []synopsisWord
+
+	for _, name := range append([]string{app.UsageName}, path...) {
+		words = append(words, synopsisWord{{text: name, style: synopsisLiteral}})
+	}
+
+	var options []synopsisWord
+	var requiredOptions []synopsisWord
+
+	for _, opt := range opts {
+		word := describeSynopsisOption(opt)
+
+		if !opt.Required {
+			word = optionalSynopsisWord(word)
+		} else {
+			requiredOptions = append(requiredOptions, word)
+		}
+
+		options = append(options, word)
+	}
+
+	var rest []synopsisWord
+
+	// A command can be run without a sub-command if it can be executed itself. If it also takes
+	// arguments, they're shown instead, as they can't be given with a sub-command.
+	executable := (cmd != nil && cmd.Execute != nil) || (cmd == nil && app.rootCommand != nil)
+
+	if hasVisibleCommands(commands) && (!executable || len(args) == 0) {
+		word := synopsisWord{{text: "COMMAND", style: synopsisPlaceholder}}
+
+		if executable {
+			word = optionalSynopsisWord(word)
+		}
+
+		rest = append(rest, word)
+	}
+
+	for _, arg := range args {
+		word := synopsisWord{{text: arg.Name, style: synopsisPlaceholder}}
+
+		if !arg.Required {
+			word = optionalSynopsisWord(word)
+		}
+
+		rest = append(rest, word)
+	}
+
+	full := append(append(append([]synopsisWord{}, words...), options...), rest...)
+	if len(options) == len(requiredOptions) || len(synopsisString(full)) <= width {
+		return full
+	}
+
+	collapsed := optionalSynopsisWord(synopsisWord{{text: "OPTIONS", style: synopsisPlaceholder}})
+
+	words = append(words, collapsed)
+	words = append(words, requiredOptions...)
+
+	return append(words, rest...)
+}
+
+// describeSynopsisOption describes how an option is given in a usage synopsis. Options that take a
+// value are shown with their longest name (e.g. `--dsn=DSN`), and those that don't are shown with
+// their shortest name (e.g. `-f`).
+func describeSynopsisOption(opt parameters.Option) synopsisWord {
+	name := opt.Names[0]
+
+	for _, n := range opt.Names {
+		isLonger := len(n) > len(name)
+		isShorter := len(n) < len(name)
+
+		if (opt.ValueMode == parameters.OptionValueNone && isShorter) || (opt.ValueMode != parameters.OptionValueNone && isLonger) {
+			name = n
+		}
+	}
+
+	word := synopsisWord{{text: optionFlag(name), style: synopsisLiteral}}
+
+	switch opt.ValueMode {
+	case parameters.OptionValueRequired:
+		word = append(word,
+			synopsisPart{text: "="},
+			synopsisPart{text: opt.ValueName, style: synopsisPlaceholder},
+		)
+	case parameters.OptionValueOptional:
+		word = append(word,
+			synopsisPart{text: "[="},
+			synopsisPart{text: opt.ValueName, style: synopsisPlaceholder},
+			synopsisPart{text: "]"},
+		)
+	}
+
+	return word
+}
+
+// optionalSynopsisWord wraps the given word in brackets, to show that it's optional.
+func optionalSynopsisWord(word synopsisWord) synopsisWord {
+	optional := synopsisWord{{text: "["}}
+	optional = append(optional, word...)
+
+	return append(optional, synopsisPart{text: "]"})
+}
+
+// synopsisString describes the given words as plain text, separated by spaces.
+func synopsisString(words []synopsisWord) string {
+	var texts []string
+	for _, word := range words {
+		texts = append(texts, word.String())
+	}
+
+	return strings.Join(texts, " ")
+}
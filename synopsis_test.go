@@ -0,0 +1,140 @@
+package console_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/seeruk/go-console"
+	"github.com/seeruk/go-console/parameters"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUsageSynopsis(t *testing.T) {
+	createApplication := func() (*console.Application, *console.Command, *console.Command) {
+		var dsn string
+		var force bool
+		var steps int
+		var timeout string
+
+		migrate := &console.Command{
+			Name: "migrate",
+			Configure: func(definition *console.Definition) {
+				definition.AddOption(console.OptionDefinition{
+					Value: parameters.NewBoolValue(&force),
+					Spec:  "-f, --force",
+				})
+
+				definition.AddOption(console.OptionDefinition{
+					Value:    parameters.NewStringValue(&dsn),
+					Spec:     "-d, --dsn=DSN",
+					Required: true,
+				})
+
+				definition.AddOption(console.OptionDefinition{
+					Value: parameters.NewStringValue(&timeout),
+					Spec:  "--lock-timeout=TIMEOUT",
+				})
+
+				definition.AddArgument(console.ArgumentDefinition{
+					Value: parameters.NewIntValue(&steps),
+					Spec:  "[STEPS]",
+				})
+			},
+			Execute: func(input *console.Input, output *console.Output) error {
+				return nil
+			},
+		}
+
+		db := &console.Command{Name: "db"}
+		db.AddCommand(migrate)
+
+		application := console.NewApplication("App", "1.2.3")
+		application.UsageName = "app"
+		application.Width = 80
+		application.AddCommand(db)
+
+		return application, db, migrate
+	}
+
+	usage := func(help string) string {
+		return strings.TrimSpace(strings.Split(help, "\n")[1])
+	}
+
+	t.Run("should show every option, and argument", func(t *testing.T) {
+		application, _, migrate := createApplication()
+
+		result := console.DescribeCommand(application, migrate, []string{"db", "migrate"})
+
+		assert.Equal(t, "app db migrate [-h] [-f] --dsn=DSN [--lock-timeout=TIMEOUT] [STEPS]", usage(result))
+	})
+
+	t.Run("should collapse options that aren't required if the line is too long", func(t *testing.T) {
+		application, _, migrate := createApplication()
+		application.Width = 40
+
+		result := console.DescribeCommand(application, migrate, []string{"db", "migrate"})
+
+		assert.Equal(t, "app db migrate [OPTIONS] --dsn=DSN [STEPS]", usage(result))
+	})
+
+	t.Run("should show that a sub-command is required", func(t *testing.T) {
+		application, db, _ := createApplication()
+
+		result := console.DescribeCommand(application, db, []string{"db"})
+
+		assert.Equal(t, "app db [-h] COMMAND", usage(result))
+	})
+
+	t.Run("should show that a sub-command is optional if the command can be executed", func(t *testing.T) {
+		application, db, _ := createApplication()
+		db.Execute = func(input *console.Input, output *console.Output) error {
+			return nil
+		}
+
+		result := console.DescribeCommand(application, db, []string{"db"})
+
+		assert.Equal(t, "app db [-h] [COMMAND]", usage(result))
+	})
+
+	t.Run("should show arguments instead of a sub-command if the command can be executed", func(t *testing.T) {
+		var name string
+
+		application, db, _ := createApplication()
+		db.Configure = func(definition *console.Definition) {
+			definition.AddArgument(console.ArgumentDefinition{
+				Value: parameters.NewStringValue(&name),
+				Spec:  "NAME",
+			})
+		}
+
+		db.Execute = func(input *console.Input, output *console.Output) error {
+			return nil
+		}
+
+		result := console.DescribeCommand(application, db, []string{"db"})
+
+		assert.Equal(t, "app db [-h] NAME", usage(result))
+	})
+
+	t.Run("should describe the application", func(t *testing.T) {
+		application, _, _ := createApplication()
+		application.EnableVersion = true
+
+		result := console.DescribeApplication(application)
+
+		assert.Contains(t, result, "USAGE:\n  app [-h] [-V] COMMAND\n")
+	})
+
+	t.Run("should show that a command is optional if the application has a root command", func(t *testing.T) {
+		application, _, _ := createApplication()
+		application.SetRootCommand(&console.Command{
+			Execute: func(input *console.Input, output *console.Output) error {
+				return nil
+			},
+		})
+
+		pages, err := console.NewDocsGenerator(application).Generate()
+		assert.NoError(t, err)
+		assert.Contains(t, pages[0].Content, "```\napp [-h] [COMMAND]\n```")
+	})
+}
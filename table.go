@@ -0,0 +1,357 @@
+package console
+
+import (
+	"strings"
+)
+
+// Column alignments.
+const (
+	AlignLeft Alignment = iota
+	AlignCenter
+	AlignRight
+)
+
+// Alignment represents how the content of a table column is aligned.
+type Alignment int
+
+// Table border styles.
+const (
+	TableBorderNone TableBorderStyle = iota
+	TableBorderASCII
+	TableBorderBox
+	TableBorderMarkdown
+)
+
+// TableBorderStyle represents the characters used to draw the borders of a table.
+type TableBorderStyle int
+
+// Table overflow modes.
+const (
+	TableOverflowWrap TableOverflow = iota
+	TableOverflowTruncate
+)
+
+// TableOverflow represents how cells that are too wide to fit in a table are handled.
+type TableOverflow int
+
+// tableBorder contains the characters used to draw a table's borders.
+type tableBorder struct {
+	horizontal string
+	vertical   string
+	// Corners and junctions, in the order: left, middle, right; for the top, middle and bottom.
+	top    [3]string
+	middle [3]string
+	bottom [3]string
+}
+
+// tableBorders maps each border style to the characters it uses to draw a table.
+var tableBorders = map[TableBorderStyle]tableBorder{
+	TableBorderASCII: {
+		horizontal: "-",
+		vertical:   "|",
+		top:        [3]string{"+", "+", "+"},
+		middle:     [3]string{"+", "+", "+"},
+		bottom:     [3]string{"+", "+", "+"},
+	},
+	TableBorderBox: {
+		horizontal: "─",
+		vertical:   "│",
+		top:        [3]string{"┌", "┬", "┐"},
+		middle:     [3]string{"├", "┼", "┤"},
+		bottom:     [3]string{"└", "┴", "┘"},
+	},
+	TableBorderMarkdown: {
+		horizontal: "-",
+		vertical:   "|",
+		middle:     [3]string{"|", "|", "|"},
+	},
+}
+
+// Table renders rows of data as a table on an Output. Cells may contain markup, which is taken into
+// account when calculating the width of each column, as is the display width of Unicode text.
+type Table struct {
+	// The style of border to draw around and between cells.
+	BorderStyle TableBorderStyle
+	// Whether or not to draw separators between each row.
+	RowSeparators bool
+	// The maximum width of the whole table. If 0, the width of the Output is used. If less than 0,
+	// the width is not limited.
+	MaxWidth int
+	// How to handle cells that don't fit in the maximum width.
+	Overflow TableOverflow
+
+	output     *Output
+	headers    []string
+	alignments []Alignment
+	rows       [][]string
+}
+
+// NewTable creates a new Table that renders to the given Output, with an ASCII border.
+func NewTable(output *Output) *Table {
+	return &Table{
+		BorderStyle: TableBorderASCII,
+		output:      output,
+	}
+}
+
+// SetHeaders sets the headers of the table's columns.
+func (t *Table) SetHeaders(headers ...string) *Table {
+	t.headers = headers
+
+	return t
+}
+
+// SetAlignments sets the alignment of the table's columns, in order. Columns without an alignment
+// are aligned to the left.
+func (t *Table) SetAlignments(alignments ...Alignment) *Table {
+	t.alignments = alignments
+
+	return t
+}
+
+// AddRow adds a row of cells to the table.
+func (t *Table) AddRow(cells ...string) *Table {
+	t.rows = append(t.rows, cells)
+
+	return t
+}
+
+// AddRows adds multiple rows of cells to the table.
+func (t *Table) AddRows(rows ...[]string) *Table {
+	t.rows = append(t.rows, rows...)
+
+	return t
+}
+
+// Render writes the table to the output, rendering any markup in it's cells.
+func (t *Table) Render() error {
+	_, err := t.output.Styled(t.String())
+
+	return err
+}
+
+// String renders the table, with markup left in place.
+func (t *Table) String() string {
+	columns := t.columnCount()
+	if columns == 0 {
+		return ""
+	}
+
+	widths := t.columnWidths(columns)
+	border, hasBorder := tableBorders[t.BorderStyle]
+
+	var result strings.Builder
+
+	if hasBorder && t.BorderStyle != TableBorderMarkdown {
+		result.WriteString(t.separator(border, border.top, widths))
+	}
+
+	if len(t.headers) > 0 {
+		result.WriteString(t.row(border, hasBorder, t.headers, widths))
+
+		switch {
+		case t.BorderStyle == TableBorderMarkdown:
+			result.WriteString(t.markdownSeparator(widths))
+		case hasBorder:
+			result.WriteString(t.separator(border, border.middle, widths))
+		}
+	}
+
+	for i, row := range t.rows {
+		if i > 0 && t.RowSeparators && hasBorder && t.BorderStyle != TableBorderMarkdown {
+			result.WriteString(t.separator(border, border.middle, widths))
+		}
+
+		result.WriteString(t.row(border, hasBorder, row, widths))
+	}
+
+	if hasBorder && t.BorderStyle != TableBorderMarkdown {
+		result.WriteString(t.separator(border, border.bottom, widths))
+	}
+
+	return result.String()
+}
+
+// columnCount finds the number of columns in the table, based on the widest row.
+func (t *Table) columnCount() int {
+	columns := len(t.headers)
+
+	for _, row := range t.rows {
+		if len(row) > columns {
+			columns = len(row)
+		}
+	}
+
+	return columns
+}
+
+// columnWidths calculates the width of each column, shrinking the widest columns if the table
+// would otherwise be wider than it's maximum width.
+func (t *Table) columnWidths(columns int) []int {
+	widths := make([]int, columns)
+
+	measure := func(cells []string) {
+		for i, cell := range cells {
+			for _, line := range t.output.formatter.layout(cell, 0, false) {
+				if width := t.visibleWidth(line); width > widths[i] {
+					widths[i] = width
+				}
+			}
+		}
+	}
+
+	measure(t.headers)
+
+	for _, row := range t.rows {
+		measure(row)
+	}
+
+	maxWidth := t.MaxWidth
+	if maxWidth == 0 {
+		maxWidth = t.output.Width()
+	}
+
+	if maxWidth < 0 {
+		return widths
+	}
+
+	// Space taken up by borders and padding around each cell.
+	overhead := 2 * (columns - 1)
+	if _, ok := tableBorders[t.BorderStyle]; ok {
+		overhead = 3*columns + 1
+	}
+
+	const minColumnWidth = 3
+
+	for {
+		total := overhead
+		widest := 0
+
+		for i, width := range widths {
+			total += width
+
+			if width > widths[widest] {
+				widest = i
+			}
+		}
+
+		if total <= maxWidth || widths[widest] <= minColumnWidth {
+			break
+		}
+
+		widths[widest]--
+	}
+
+	return widths
+}
+
+// row renders a single row of the table, which may span multiple lines if cells are wrapped.
+func (t *Table) row(border tableBorder, hasBorder bool, cells []string, widths []int) string {
+	var cellLines [][]string
+	var height int
+
+	for i := range widths {
+		var lines []string
+
+		if i < len(cells) {
+			lines = t.output.formatter.layout(cells[i], widths[i], t.Overflow == TableOverflowTruncate)
+		}
+
+		if len(lines) > height {
+			height = len(lines)
+		}
+
+		cellLines = append(cellLines, lines)
+	}
+
+	var result strings.Builder
+
+	for l := 0; l < height || l == 0; l++ {
+		var parts []string
+
+		for i, width := range widths {
+			var line string
+			if l < len(cellLines[i]) {
+				line = cellLines[i][l]
+			}
+
+			parts = append(parts, t.pad(line, width, t.alignment(i)))
+		}
+
+		if hasBorder {
+			result.WriteString(border.vertical + " ")
+			result.WriteString(strings.Join(parts, " "+border.vertical+" "))
+			result.WriteString(" " + border.vertical + "\n")
+		} else {
+			result.WriteString(strings.TrimRight(strings.Join(parts, "  "), " ") + "\n")
+		}
+	}
+
+	return result.String()
+}
+
+// separator renders a horizontal border line using the given corner and junction characters.
+func (t *Table) separator(border tableBorder, junctions [3]string, widths []int) string {
+	var parts []string
+
+	for _, width := range widths {
+		parts = append(parts, strings.Repeat(border.horizontal, width+2))
+	}
+
+	return junctions[0] + strings.Join(parts, junctions[1]) + junctions[2] + "\n"
+}
+
+// markdownSeparator renders the line between the header and body of a Markdown table, which also
+// specifies the alignment of each column.
+func (t *Table) markdownSeparator(widths []int) string {
+	var parts []string
+
+	for i, width := range widths {
+		dashes := strings.Repeat("-", width)
+
+		switch t.alignment(i) {
+		case AlignCenter:
+			dashes = ":" + dashes + ":"
+		case AlignRight:
+			dashes = "-" + dashes + ":"
+		default:
+			dashes = "-" + dashes + "-"
+		}
+
+		parts = append(parts, dashes)
+	}
+
+	return "|" + strings.Join(parts, "|") + "|\n"
+}
+
+// alignment gets the alignment of the column at the given index.
+func (t *Table) alignment(column int) Alignment {
+	if column < len(t.alignments) {
+		return t.alignments[column]
+	}
+
+	return AlignLeft
+}
+
+// pad pads the given line of a cell with spaces to the given width, using the given alignment.
+func (t *Table) pad(line string, width int, alignment Alignment) string {
+	space := width - t.visibleWidth(line)
+	if space <= 0 {
+		return line
+	}
+
+	switch alignment {
+	case AlignRight:
+		return strings.Repeat(" ", space) + line
+	case AlignCenter:
+		left := space / 2
+		return strings.Repeat(" ", left) + line + strings.Repeat(" ", space-left)
+	}
+
+	return line + strings.Repeat(" ", space)
+}
+
+// visibleWidth gets the display width of the given text, ignoring markup.
+func (t *Table) visibleWidth(text string) int {
+	return stringWidth(t.output.formatter.Format(text, false))
+}
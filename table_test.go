@@ -0,0 +1,193 @@
+package console_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/seeruk/go-console"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTable(t *testing.T) {
+	createTable := func(buffer *bytes.Buffer) *console.Table {
+		table := console.NewTable(console.NewOutput(buffer))
+		table.SetHeaders("Name", "Age")
+		table.AddRow("Alice", "30")
+		table.AddRow("Bob", "4")
+
+		return table
+	}
+
+	t.Run("should render an ASCII table by default", func(t *testing.T) {
+		buffer := bytes.Buffer{}
+
+		err := createTable(&buffer).Render()
+
+		expected := strings.Join([]string{
+			"+-------+-----+",
+			"| Name  | Age |",
+			"+-------+-----+",
+			"| Alice | 30  |",
+			"| Bob   | 4   |",
+			"+-------+-----+",
+			"",
+		}, "\n")
+
+		assert.NoError(t, err)
+		assert.Equal(t, expected, buffer.String())
+	})
+
+	t.Run("should render box-drawing borders and row separators", func(t *testing.T) {
+		buffer := bytes.Buffer{}
+
+		table := createTable(&buffer)
+		table.BorderStyle = console.TableBorderBox
+		table.RowSeparators = true
+		table.Render()
+
+		expected := strings.Join([]string{
+			"┌───────┬─────┐",
+			"│ Name  │ Age │",
+			"├───────┼─────┤",
+			"│ Alice │ 30  │",
+			"├───────┼─────┤",
+			"│ Bob   │ 4   │",
+			"└───────┴─────┘",
+			"",
+		}, "\n")
+
+		assert.Equal(t, expected, buffer.String())
+	})
+
+	t.Run("should render markdown tables with alignment", func(t *testing.T) {
+		buffer := bytes.Buffer{}
+
+		table := createTable(&buffer)
+		table.BorderStyle = console.TableBorderMarkdown
+		table.SetAlignments(console.AlignLeft, console.AlignRight)
+		table.Render()
+
+		expected := strings.Join([]string{
+			"| Name  | Age |",
+			"|-------|----:|",
+			"| Alice |  30 |",
+			"| Bob   |   4 |",
+			"",
+		}, "\n")
+
+		assert.Equal(t, expected, buffer.String())
+	})
+
+	t.Run("should render tables without borders", func(t *testing.T) {
+		buffer := bytes.Buffer{}
+
+		table := createTable(&buffer)
+		table.BorderStyle = console.TableBorderNone
+		table.SetAlignments(console.AlignCenter)
+		table.Render()
+
+		expected := strings.Join([]string{
+			"Name   Age",
+			"Alice  30",
+			" Bob   4",
+			"",
+		}, "\n")
+
+		assert.Equal(t, expected, buffer.String())
+	})
+
+	t.Run("should calculate widths ignoring markup", func(t *testing.T) {
+		buffer := bytes.Buffer{}
+
+		table := console.NewTable(console.NewOutput(&buffer))
+		table.AddRow("<info>ok</info>", "x")
+		table.AddRow("fail", "y")
+		table.Render()
+
+		assert.Contains(t, buffer.String(), "| ok   | x |")
+	})
+
+	t.Run("should calculate widths of wide characters", func(t *testing.T) {
+		buffer := bytes.Buffer{}
+
+		table := console.NewTable(console.NewOutput(&buffer))
+		table.AddRow("日本", "x")
+		table.AddRow("abc", "y")
+		table.Render()
+
+		assert.Contains(t, buffer.String(), "| 日本 | x |")
+		assert.Contains(t, buffer.String(), "| abc  | y |")
+	})
+
+	t.Run("should use the width of the output by default", func(t *testing.T) {
+		buffer := bytes.Buffer{}
+
+		output := console.NewOutput(&buffer)
+		output.SetWidth(console.MinWidth)
+
+		table := console.NewTable(output)
+		table.AddRow("id", strings.Repeat("word ", 20))
+		table.Render()
+
+		for _, line := range strings.Split(strings.TrimSpace(buffer.String()), "\n") {
+			assert.True(t, len(line) <= console.MinWidth, "Expected line to fit in output width.")
+		}
+	})
+
+	t.Run("should not limit the width if the maximum width is negative", func(t *testing.T) {
+		buffer := bytes.Buffer{}
+
+		output := console.NewOutput(&buffer)
+		output.SetWidth(console.MinWidth)
+
+		table := console.NewTable(output)
+		table.MaxWidth = -1
+		table.AddRow("id", strings.Repeat("word ", 20))
+		table.Render()
+
+		assert.Len(t, strings.Split(strings.TrimSpace(buffer.String()), "\n"), 3)
+	})
+
+	t.Run("should wrap cells that are too wide", func(t *testing.T) {
+		buffer := bytes.Buffer{}
+
+		table := console.NewTable(console.NewOutput(&buffer))
+		table.MaxWidth = 16
+		table.AddRow("id", "the quick brown fox")
+		table.Render()
+
+		expected := strings.Join([]string{
+			"+----+---------+",
+			"| id | the     |",
+			"|    | quick   |",
+			"|    | brown   |",
+			"|    | fox     |",
+			"+----+---------+",
+			"",
+		}, "\n")
+
+		assert.Equal(t, expected, buffer.String())
+	})
+
+	t.Run("should truncate cells that are too wide if requested", func(t *testing.T) {
+		buffer := bytes.Buffer{}
+
+		table := console.NewTable(console.NewOutput(&buffer))
+		table.MaxWidth = 16
+		table.Overflow = console.TableOverflowTruncate
+		table.AddRow("id", "the quick brown fox")
+		table.Render()
+
+		assert.Contains(t, buffer.String(), "| id | the qu… |")
+	})
+
+	t.Run("should keep markup balanced on wrapped lines", func(t *testing.T) {
+		table := console.NewTable(console.NewOutput(&bytes.Buffer{}))
+		table.MaxWidth = 9
+		table.AddRow("<info>aaa bbb</info>")
+
+		assert.Contains(t, table.String(), "| <info>aaa</>   |")
+		assert.Contains(t, table.String(), "| <info>bbb</info>   |")
+	})
+}
@@ -0,0 +1,56 @@
+package console
+
+import (
+	"io"
+	"os"
+	"strconv"
+
+	"github.com/seeruk/go-console/parameters"
+)
+
+// isTerminal checks to see if the given reader or writer is a terminal. Only files can be
+// terminals, so anything else (e.g. a buffer in tests) is never considered to be one. Files that
+// are character devices, but not terminals (e.g. /dev/null) aren't either.
+func isTerminal(v interface{}) bool {
+	file, ok := v.(*os.File)
+	if !ok || file == nil {
+		return false
+	}
+
+	return isTerminalFile(file)
+}
+
+// Limits on the width that output is wrapped to.
+const (
+	// DefaultWidth is the width used when the width of the terminal can't be detected.
+	DefaultWidth = parameters.DefaultWidth
+	// MinWidth is the narrowest width that output will be wrapped to.
+	MinWidth = 40
+	// MaxWidth is the widest width that output will be wrapped to. Text wider than this becomes
+	// hard to read, even on very wide terminals.
+	MaxWidth = 120
+)
+
+// detectWidth finds the width that output written to the given writer should be wrapped to. The
+// given width is used if it's greater than 0, then the COLUMNS environment variable, then the size
+// of the terminal the writer is attached to. The result is kept between MinWidth and MaxWidth.
+func detectWidth(width int, writer io.Writer, env []string) int {
+	if width <= 0 {
+		width, _ = strconv.Atoi(lookupEnv(env, "COLUMNS"))
+	}
+
+	if file, ok := writer.(*os.File); ok && width <= 0 && isTerminal(file) {
+		width = terminalWidth(file)
+	}
+
+	switch {
+	case width <= 0:
+		return DefaultWidth
+	case width < MinWidth:
+		return MinWidth
+	case width > MaxWidth:
+		return MaxWidth
+	}
+
+	return width
+}
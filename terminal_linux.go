@@ -0,0 +1,102 @@
+//go:build linux
+// +build linux
+
+package console
+
+import (
+	"os"
+	"os/signal"
+	"sync"
+	"syscall"
+	"unsafe"
+)
+
+// disableEcho turns off echoing of typed characters on the given terminal, returning a function
+// that restores the terminal to it's previous state. If the application is interrupted or terminated
+// before then, the terminal is restored before the signal is handled as it would have been.
+func disableEcho(file *os.File) (func(), error) {
+	var state syscall.Termios
+
+	fd := file.Fd()
+
+	if err := ioctl(fd, syscall.TCGETS, &state); err != nil {
+		return nil, err
+	}
+
+	noEcho := state
+	noEcho.Lflag &^= syscall.ECHO
+	noEcho.Lflag |= syscall.ICANON | syscall.ISIG
+
+	if err := ioctl(fd, syscall.TCSETS, &noEcho); err != nil {
+		return nil, err
+	}
+
+	var once sync.Once
+
+	restore := func() {
+		once.Do(func() {
+			ioctl(fd, syscall.TCSETS, &state)
+		})
+	}
+
+	signals := make(chan os.Signal, 1)
+	done := make(chan struct{})
+
+	signal.Notify(signals, os.Interrupt, syscall.SIGTERM)
+
+	go func() {
+		select {
+		case sig := <-signals:
+			restore()
+
+			// Stop listening, and send the signal again, so that it's handled as it would have been
+			// if echo hadn't been disabled (e.g. by stopping the application).
+			signal.Stop(signals)
+			syscall.Kill(os.Getpid(), sig.(syscall.Signal))
+		case <-done:
+		}
+	}()
+
+	return func() {
+		signal.Stop(signals)
+		close(done)
+		restore()
+	}, nil
+}
+
+// isTerminalFile checks to see if the given file is a terminal, by reading it's terminal state.
+func isTerminalFile(file *os.File) bool {
+	var state syscall.Termios
+
+	return ioctl(file.Fd(), syscall.TCGETS, &state) == nil
+}
+
+// terminalWidth finds the number of columns in the given terminal, returning 0 if it can't be found.
+func terminalWidth(file *os.File) int {
+	var size struct {
+		rows, cols, xpixels, ypixels uint16
+	}
+
+	_, _, errno := syscall.Syscall(
+		syscall.SYS_IOCTL,
+		file.Fd(),
+		syscall.TIOCGWINSZ,
+		uintptr(unsafe.Pointer(&size)),
+	)
+
+	if errno != 0 {
+		return 0
+	}
+
+	return int(size.cols)
+}
+
+// ioctl performs an ioctl system call that reads or writes terminal state.
+func ioctl(fd uintptr, request uintptr, state *syscall.Termios) error {
+	_, _, errno := syscall.Syscall(syscall.SYS_IOCTL, fd, request, uintptr(unsafe.Pointer(state)))
+	if errno != 0 {
+		return errno
+	}
+
+	return nil
+}
@@ -0,0 +1,30 @@
+//go:build !linux && !windows
+// +build !linux,!windows
+
+package console
+
+import (
+	"errors"
+	"os"
+)
+
+// disableEcho is not supported on this platform.
+func disableEcho(file *os.File) (func(), error) {
+	return nil, errors.New("hiding input is not supported on this platform")
+}
+
+// isTerminalFile checks to see if the given file is a character device. Terminal state can't be read
+// on this platform, so other character devices (e.g. /dev/null) are considered terminals too.
+func isTerminalFile(file *os.File) bool {
+	stat, err := file.Stat()
+	if err != nil {
+		return false
+	}
+
+	return stat.Mode()&os.ModeCharDevice != 0
+}
+
+// terminalWidth is not supported on this platform, so the width is never found.
+func terminalWidth(file *os.File) int {
+	return 0
+}
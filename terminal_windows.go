@@ -0,0 +1,27 @@
+//go:build windows
+// +build windows
+
+package console
+
+import (
+	"errors"
+	"os"
+	"syscall"
+)
+
+// disableEcho is not supported on this platform.
+func disableEcho(file *os.File) (func(), error) {
+	return nil, errors.New("hiding input is not supported on this platform")
+}
+
+// isTerminalFile checks to see if the given file is a console, by reading it's console mode.
+func isTerminalFile(file *os.File) bool {
+	var mode uint32
+
+	return syscall.GetConsoleMode(syscall.Handle(file.Fd()), &mode) == nil
+}
+
+// terminalWidth is not supported on this platform, so the width is never found.
+func terminalWidth(file *os.File) int {
+	return 0
+}
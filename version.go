@@ -0,0 +1,113 @@
+package console
+
+import (
+	"fmt"
+	"runtime"
+	"runtime/debug"
+
+	"github.com/seeruk/go-console/parameters"
+)
+
+// VersionInfo contains information about the version of an application, and the build that
+// produced the running binary.
+type VersionInfo struct {
+	// The name of the application.
+	Name string `json:"name" yaml:"name"`
+	// The version of the application, as set on the Application.
+	Version string `json:"version" yaml:"version"`
+	// The version of the main module, as recorded by the Go toolchain.
+	ModuleVersion string `json:"moduleVersion,omitempty" yaml:"moduleVersion,omitempty"`
+	// The VCS revision the binary was built from.
+	Revision string `json:"revision,omitempty" yaml:"revision,omitempty"`
+	// The time of the VCS revision the binary was built from.
+	RevisionTime string `json:"revisionTime,omitempty" yaml:"revisionTime,omitempty"`
+	// Whether or not the VCS working tree had uncommitted changes at build time.
+	Dirty bool `json:"dirty" yaml:"dirty"`
+	// The version of Go used to build the binary.
+	GoVersion string `json:"goVersion" yaml:"goVersion"`
+}
+
+// NewVersionInfo creates a new VersionInfo for the given application, reading build metadata
+// embedded in the running binary where it is available.
+func NewVersionInfo(app *Application) VersionInfo {
+	info := VersionInfo{
+		Name:      app.Name,
+		Version:   app.Version,
+		GoVersion: runtime.Version(),
+	}
+
+	buildInfo, ok := debug.ReadBuildInfo()
+	if !ok {
+		return info
+	}
+
+	info.ModuleVersion = buildInfo.Main.Version
+
+	readBuildSettings(&info, buildInfo)
+
+	return info
+}
+
+// DescribeVersion describes VersionInfo in a human-readable way.
+func DescribeVersion(info VersionInfo) string {
+	desc := fmt.Sprintf("%s version %s\n", info.Name, info.Version)
+
+	if info.ModuleVersion != "" {
+		desc += fmt.Sprintf("  Module version: %s\n", info.ModuleVersion)
+	}
+
+	if info.Revision != "" {
+		revision := info.Revision
+		if info.Dirty {
+			revision += " (dirty)"
+		}
+
+		desc += fmt.Sprintf("  Revision:       %s\n", revision)
+	}
+
+	if info.RevisionTime != "" {
+		desc += fmt.Sprintf("  Revision time:  %s\n", info.RevisionTime)
+	}
+
+	desc += fmt.Sprintf("  Go version:     %s\n", info.GoVersion)
+
+	return desc
+}
+
+// String describes the VersionInfo in a human-readable way (see DescribeVersion).
+func (i VersionInfo) String() string {
+	return DescribeVersion(i)
+}
+
+// newVersionCommand creates the built-in version command for the given application. If the
+// application has the output format option enabled then that is used to pick the output format,
+// otherwise the command has it's own output option.
+func newVersionCommand(app *Application) *Command {
+	var format string
+
+	return &Command{
+		Name:          "version",
+		Description:   "Display version information.",
+		OutputFormats: []string{FormatText, FormatJSON, FormatYAML, FormatTemplate},
+		Configure: func(definition *Definition) {
+			if app.EnableOutputFormat {
+				return
+			}
+
+			definition.AddOption(OptionDefinition{
+				Value: parameters.NewStringValue(&format),
+				Spec:  "--output=FORMAT",
+				Desc:  "Output format, one of: text, json, yaml, or template=TEMPLATE.",
+			})
+		},
+		Execute: func(input *Input, output *Output) error {
+			if format != "" {
+				if err := output.SetFormat(format); err != nil {
+					return err
+				}
+			}
+
+			return output.Render(NewVersionInfo(app))
+		},
+	}
+}
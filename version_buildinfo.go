@@ -0,0 +1,25 @@
+//go:build go1.18
+// +build go1.18
+
+package console
+
+import "runtime/debug"
+
+// readBuildSettings reads the Go version, and VCS information recorded in the given build info into
+// the given VersionInfo.
+func readBuildSettings(info *VersionInfo, buildInfo *debug.BuildInfo) {
+	if buildInfo.GoVersion != "" {
+		info.GoVersion = buildInfo.GoVersion
+	}
+
+	for _, setting := range buildInfo.Settings {
+		switch setting.Key {
+		case "vcs.revision":
+			info.Revision = setting.Value
+		case "vcs.time":
+			info.RevisionTime = setting.Value
+		case "vcs.modified":
+			info.Dirty = setting.Value == "true"
+		}
+	}
+}
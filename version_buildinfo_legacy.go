@@ -0,0 +1,10 @@
+//go:build !go1.18
+// +build !go1.18
+
+package console
+
+import "runtime/debug"
+
+// readBuildSettings does nothing, as Go versions before 1.18 don't record build settings, so only
+// the module version, and the Go version of the runtime are available.
+func readBuildSettings(info *VersionInfo, buildInfo *debug.BuildInfo) {}
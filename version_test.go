@@ -0,0 +1,169 @@
+package console_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"strings"
+	"testing"
+
+	"github.com/seeruk/go-console"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewVersionInfo(t *testing.T) {
+	t.Run("should include the application name and version", func(t *testing.T) {
+		application := console.NewApplication("seeruk/go-console", "1.2.3+testing")
+
+		info := console.NewVersionInfo(application)
+
+		assert.Equal(t, "seeruk/go-console", info.Name)
+		assert.Equal(t, "1.2.3+testing", info.Version)
+	})
+
+	t.Run("should include the Go version", func(t *testing.T) {
+		application := console.NewApplication("seeruk/go-console", "1.2.3+testing")
+
+		info := console.NewVersionInfo(application)
+
+		assert.True(t, strings.HasPrefix(info.GoVersion, "go"), "Expected Go version.")
+	})
+}
+
+func TestDescribeVersion(t *testing.T) {
+	t.Run("should include the name and version", func(t *testing.T) {
+		result := console.DescribeVersion(console.VersionInfo{
+			Name:    "seeruk/go-console",
+			Version: "1.2.3+testing",
+		})
+
+		assert.True(t, strings.Contains(result, "seeruk/go-console version 1.2.3+testing"), "Expected version.")
+	})
+
+	t.Run("should mark dirty revisions", func(t *testing.T) {
+		result := console.DescribeVersion(console.VersionInfo{
+			Revision: "abc123",
+			Dirty:    true,
+		})
+
+		assert.True(t, strings.Contains(result, "abc123 (dirty)"), "Expected dirty revision.")
+	})
+}
+
+func TestVersion(t *testing.T) {
+	createApplication := func(writer *bytes.Buffer) *console.Application {
+		application := console.NewApplication("seeruk/go-console", "1.2.3+testing")
+		application.Writer = writer
+		application.ErrWriter = ioutil.Discard
+		application.EnableVersion = true
+
+		return application
+	}
+
+	t.Run("should show the version if the version flag is set", func(t *testing.T) {
+		for _, flag := range []string{"--version", "-V"} {
+			writer := bytes.Buffer{}
+			application := createApplication(&writer)
+
+			code := application.Run([]string{flag}, []string{})
+
+			assert.Equal(t, 0, code)
+			assert.True(t, strings.Contains(writer.String(), "version 1.2.3+testing"), "Expected version.")
+		}
+	})
+
+	t.Run("should show the version as text if the version flag is set", func(t *testing.T) {
+		writer := bytes.Buffer{}
+		application := createApplication(&writer)
+
+		code := application.Run([]string{"--version"}, []string{})
+
+		assert.Equal(t, 0, code)
+		assert.Equal(t, console.DescribeVersion(console.NewVersionInfo(application)), writer.String())
+	})
+
+	t.Run("should output JSON from the version flag if requested", func(t *testing.T) {
+		for _, args := range [][]string{{"--version", "--output=json"}, {"-V", "-o", "json"}} {
+			writer := bytes.Buffer{}
+			application := createApplication(&writer)
+			application.EnableOutputFormat = true
+
+			code := application.Run(args, []string{})
+
+			var info console.VersionInfo
+
+			assert.Equal(t, 0, code)
+			assert.NoError(t, json.Unmarshal(writer.Bytes(), &info))
+			assert.Equal(t, "1.2.3+testing", info.Version)
+		}
+	})
+
+	t.Run("should fail if an unknown output format is requested with the version flag", func(t *testing.T) {
+		writer := bytes.Buffer{}
+		application := createApplication(&writer)
+		application.EnableOutputFormat = true
+
+		code := application.Run([]string{"--version", "--output=xml"}, []string{})
+
+		assert.Equal(t, 1, code)
+	})
+
+	t.Run("should not handle the version flag unless enabled", func(t *testing.T) {
+		writer := bytes.Buffer{}
+		application := createApplication(&writer)
+		application.EnableVersion = false
+
+		code := application.Run([]string{"--version"}, []string{})
+
+		assert.Equal(t, 100, code)
+		assert.False(t, strings.Contains(writer.String(), "-V, --version"), "Expected no version option.")
+	})
+
+	t.Run("should show the version option in help", func(t *testing.T) {
+		result := console.DescribeApplication(createApplication(&bytes.Buffer{}))
+
+		assert.True(t, strings.Contains(result, "-V, --version"), "Expected version option.")
+	})
+
+	t.Run("should add a version command", func(t *testing.T) {
+		writer := bytes.Buffer{}
+		application := createApplication(&writer)
+
+		code := application.Run([]string{"version"}, []string{})
+
+		assert.Equal(t, 0, code)
+		assert.True(t, strings.Contains(writer.String(), "Go version:"), "Expected version.")
+	})
+
+	t.Run("should output JSON from the version command if requested", func(t *testing.T) {
+		writer := bytes.Buffer{}
+		application := createApplication(&writer)
+
+		code := application.Run([]string{"version", "--output=json"}, []string{})
+
+		var info console.VersionInfo
+
+		assert.Equal(t, 0, code)
+		assert.NoError(t, json.Unmarshal(writer.Bytes(), &info))
+		assert.Equal(t, "1.2.3+testing", info.Version)
+	})
+
+	t.Run("should fail if an unknown output format is requested", func(t *testing.T) {
+		writer := bytes.Buffer{}
+		application := createApplication(&writer)
+
+		code := application.Run([]string{"version", "--output=xml"}, []string{})
+
+		assert.Equal(t, 1, code)
+	})
+
+	t.Run("should not override a user-defined version command", func(t *testing.T) {
+		command := &console.Command{Name: "version"}
+
+		application := createApplication(&bytes.Buffer{})
+		application.AddCommand(command)
+
+		assert.Equal(t, 1, len(application.Commands()))
+		assert.Equal(t, command, application.Commands()[0])
+	})
+}